@@ -0,0 +1,174 @@
+// Package baseline records known issues, so a linter run on a code base
+// which does not follow the style yet reports only new issues.
+//
+// Issues are matched by the file, the rule code and the hash of the text of the
+// enclosing declaration instead of positions, so edits of other declarations
+// do not invalidate the baseline.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const DefaultFilename = ".nbs-baseline.json"
+
+// version 1 matched issues by the analyzer and category instead of the rule
+// code.
+const version = 2
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Entry struct {
+	// File is the slash separated path relative to the baseline file.
+	File string `json:"file"`
+	// Rule is the code of the rule, e.g. NBS-SEP-004, or the analyzer and
+	// category for analyzers of other repositories.
+	Rule string `json:"rule"`
+	Hash string `json:"hash"`
+	// Count is the number of issues with the same key.
+	Count int `json:"count"`
+}
+
+func (e Entry) key() string {
+	return e.File + "\x00" + e.Rule + "\x00" + e.Hash
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// New records the issues, paths are made relative to the directory of the
+// baseline file.
+func New(dir string, issues []driver.Issue) (*Baseline, error) {
+	indexes := make(map[string]int)
+	baseline := &Baseline{Version: version, Entries: make([]Entry, 0)}
+	for _, issue := range issues {
+		entry, err := newEntry(dir, issue)
+		if err != nil {
+			return nil, err
+		}
+
+		if index, ok := indexes[entry.key()]; ok {
+			baseline.Entries[index].Count++
+			continue
+		}
+
+		entry.Count = 1
+		indexes[entry.key()] = len(baseline.Entries)
+		baseline.Entries = append(baseline.Entries, entry)
+	}
+
+	slices.SortFunc(baseline.Entries, func(a, b Entry) int {
+		return strings.Compare(a.key(), b.key())
+	})
+
+	return baseline, nil
+}
+
+func Read(filename string) (*Baseline, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if baseline.Version != version {
+		return nil, fmt.Errorf(
+			"%s: unsupported baseline version %d",
+			filename,
+			baseline.Version,
+		)
+	}
+
+	return &baseline, nil
+}
+
+func (b *Baseline) Write(filename string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
+
+// Filter returns the issues which are not in the baseline. If there are
+// more issues with the same key than recorded, the last ones are new.
+func (b *Baseline) Filter(dir string, issues []driver.Issue) ([]driver.Issue, error) {
+	remaining := make(map[string]int, len(b.Entries))
+	for _, entry := range b.Entries {
+		remaining[entry.key()] += entry.Count
+	}
+
+	var result []driver.Issue
+	for _, issue := range issues {
+		entry, err := newEntry(dir, issue)
+		if err != nil {
+			return nil, err
+		}
+
+		if remaining[entry.key()] > 0 {
+			remaining[entry.key()]--
+			continue
+		}
+
+		result = append(result, issue)
+	}
+
+	return result, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newEntry(dir string, issue driver.Issue) (Entry, error) {
+	file, err := relativePath(dir, issue.Start.Filename)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	rule := issue.Code
+	if rule == "" {
+		rule = issue.Rule()
+	}
+
+	return Entry{
+		File: file,
+		Rule: rule,
+		Hash: issue.DeclarationHash,
+	}, nil
+}
+
+func relativePath(dir string, filename string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := filepath.Rel(absDir, absFilename)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(path), nil
+}
@@ -0,0 +1,74 @@
+package baseline
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+)
+
+func newIssue(
+	dir string,
+	file string,
+	line int,
+	code string,
+	hash string,
+) driver.Issue {
+
+	return driver.Issue{
+		Analyzer: "SeparatorAnalyzer",
+		Category: "separator",
+		Code:     code,
+		Message:  "Missing Separator",
+		Start: token.Position{
+			Filename: filepath.Join(dir, file),
+			Line:     line,
+		},
+		DeclarationHash: hash,
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	dir := t.TempDir()
+	issues := []driver.Issue{
+		newIssue(dir, "a.go", 10, "NBS-SEP-005", "aaaa"),
+		newIssue(dir, "a.go", 12, "NBS-SEP-005", "aaaa"),
+		newIssue(dir, "pkg/b.go", 5, "", "bbbb"),
+	}
+
+	baseline, err := New(dir, issues)
+	require.NoError(t, err)
+	require.Equal(t, []Entry{
+		{File: "a.go", Rule: "NBS-SEP-005", Hash: "aaaa", Count: 2},
+		{File: "pkg/b.go", Rule: "SeparatorAnalyzer/separator", Hash: "bbbb", Count: 1},
+	}, baseline.Entries)
+
+	filename := filepath.Join(dir, DefaultFilename)
+	require.NoError(t, baseline.Write(filename))
+	baseline, err = Read(filename)
+	require.NoError(t, err)
+
+	// Lines moved, a third issue and an issue of another rule appeared in the
+	// same declaration and a declaration changed.
+	current := []driver.Issue{
+		newIssue(dir, "a.go", 20, "NBS-SEP-005", "aaaa"),
+		newIssue(dir, "a.go", 22, "NBS-SEP-005", "aaaa"),
+		newIssue(dir, "a.go", 24, "NBS-SEP-005", "aaaa"),
+		newIssue(dir, "a.go", 26, "NBS-SEP-009", "aaaa"),
+		newIssue(dir, "pkg/b.go", 5, "", "cccc"),
+	}
+	filtered, err := baseline.Filter(dir, current)
+	require.NoError(t, err)
+	require.Equal(t, current[2:], filtered)
+}
+
+func TestReadUnsupportedVersion(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), DefaultFilename)
+	require.NoError(t, (&Baseline{Version: 1}).Write(filename))
+
+	_, err := Read(filename)
+	require.ErrorContains(t, err, "unsupported baseline version 1")
+}
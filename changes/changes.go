@@ -0,0 +1,302 @@
+// Package changes finds lines changed since a git revision or by a patch,
+// so issues can be limited to new code.
+//
+// An issue is new if one of its lines or of its related ranges changed.
+// Issues of section rules of SeparatorAnalyzer are new if any line of their
+// section changed.
+package changes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/token"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// RepositoryRoot returns the top-level directory of the git repository
+// containing the directory.
+func RepositoryRoot(dir string) (string, error) {
+	output, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.FromSlash(strings.TrimSpace(string(output))), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Changes are the changed lines by absolute file name.
+type Changes struct {
+	files map[string]*file
+}
+
+// FromRev returns the changes of the working tree of the repository
+// containing the directory since the revision, untracked files are new as a
+// whole.
+func FromRev(dir string, rev string) (*Changes, error) {
+	root, err := RepositoryRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefixes are set explicitly, as diff.noprefix and diff.mnemonicPrefix
+	// settings change them.
+	patch, err := git(
+		root,
+		"diff",
+		"--no-color",
+		"--no-ext-diff",
+		"--src-prefix=a/",
+		"--dst-prefix=b/",
+		"--unified=0",
+		rev,
+		"--",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := ParsePatch(root, bytes.NewReader(patch))
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := git(root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range strings.Split(string(untracked), "\x00") {
+		if name != "" {
+			changes.file(filepath.Join(root, filepath.FromSlash(name))).whole = true
+		}
+	}
+
+	return changes, nil
+}
+
+// ParsePatch reads changed lines from a unified diff, paths in the diff are
+// relative to the root directory. A deletion changes the line following it.
+func ParsePatch(root string, patch io.Reader) (*Changes, error) {
+	changes := &Changes{files: make(map[string]*file)}
+	scanner := bufio.NewScanner(patch)
+	scanner.Buffer(nil, 16*1024*1024)
+
+	var current *file
+	line, oldLeft, newLeft := 0, 0, 0
+	for scanner.Scan() {
+		text := scanner.Text()
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				current.add(line)
+				line++
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				current.add(line)
+				oldLeft--
+			case strings.HasPrefix(text, " ") || text == "":
+				line++
+				oldLeft--
+				newLeft--
+			}
+
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(text, "+++ "); ok {
+			current = nil
+			if name = patchPath(name); name != "" {
+				current = changes.file(filepath.Join(root, filepath.FromSlash(name)))
+			}
+
+			continue
+		}
+
+		if !strings.HasPrefix(text, "@@ ") || current == nil {
+			continue
+		}
+
+		var err error
+		line, oldLeft, newLeft, err = parseHunkHeader(text)
+		if err != nil {
+			return nil, err
+		}
+
+		// Hunks without new lines start at the line before the deletion.
+		if newLeft == 0 {
+			line++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// Filter returns the issues in changed lines.
+func (c *Changes) Filter(issues []driver.Issue) []driver.Issue {
+	var result []driver.Issue
+	for _, issue := range issues {
+		if c.isNew(issue) {
+			result = append(result, issue)
+		}
+	}
+
+	return result
+}
+
+func (c *Changes) isNew(issue driver.Issue) bool {
+	if c.changed(issue.Start, issue.End) {
+		return true
+	}
+
+	if issue.Section != nil && c.changed(issue.Section.Start, issue.Section.End) {
+		return true
+	}
+
+	for _, related := range issue.Related {
+		if c.changed(related.Start, related.End) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Changes) changed(start, end token.Position) bool {
+	file, ok := c.files[resolvePath(start.Filename)]
+	if !ok {
+		return false
+	}
+
+	if file.whole {
+		return true
+	}
+
+	for line := start.Line; line <= max(start.Line, end.Line); line++ {
+		if _, ok := file.lines[line]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Changes) file(filename string) *file {
+	filename = resolvePath(filename)
+	if _, ok := c.files[filename]; !ok {
+		c.files[filename] = &file{lines: make(map[int]struct{})}
+	}
+
+	return c.files[filename]
+}
+
+// resolvePath returns the absolute path without symlinks, as git returns
+// the resolved root while file names of issues may go through a symlink.
+func resolvePath(filename string) string {
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return filename
+	}
+
+	resolved, err := filepath.EvalSymlinks(absFilename)
+	if err != nil {
+		return absFilename
+	}
+
+	return resolved
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type file struct {
+	// whole is set for new files which are not in the diff.
+	whole bool
+	lines map[int]struct{}
+}
+
+func (f *file) add(line int) {
+	f.lines[line] = struct{}{}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"git %s: %w: %s",
+			strings.Join(args, " "),
+			err,
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+
+	return output, nil
+}
+
+// patchPath strips the "b/" prefix of git and the timestamp of diff -u from
+// the path of the new file, deleted files have no path.
+func patchPath(text string) string {
+	name, _, _ := strings.Cut(text, "\t")
+	if name == "/dev/null" {
+		return ""
+	}
+
+	return strings.TrimPrefix(name, "b/")
+}
+
+// parseHunkHeader returns the first line of the new side of a hunk and the
+// numbers of lines of both sides: "@@ -1,2 +3,4 @@".
+func parseHunkHeader(text string) (int, int, int, error) {
+	fields := strings.Fields(text)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", text)
+	}
+
+	_, oldCount, err := parseRange(fields[1][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q: %w", text, err)
+	}
+
+	newStart, newCount, err := parseRange(fields[2][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q: %w", text, err)
+	}
+
+	return newStart, oldCount, newCount, nil
+}
+
+// parseRange parses "start,count" where the count defaults to one.
+func parseRange(text string) (int, int, error) {
+	startText, countText, hasCount := strings.Cut(text, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !hasCount {
+		return start, 1, nil
+	}
+
+	count, err := strconv.Atoi(countText)
+	return start, count, err
+}
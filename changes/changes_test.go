@@ -0,0 +1,125 @@
+package changes
+
+import (
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+)
+
+const patch = `diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -3,0 +4,2 @@ package a
++func added() {
++}
+@@ -10,2 +12 @@ func b() {
+-	first()
+-	second()
++	both()
+@@ -20 +20,0 @@ func c() {
+-	removed()
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package a
+-
+`
+
+func TestParsePatch(t *testing.T) {
+	root := t.TempDir()
+	changes, err := ParsePatch(root, strings.NewReader(patch))
+	require.NoError(t, err)
+
+	filename := filepath.Join(root, "a.go")
+	issue := func(start, end int) driver.Issue {
+		return driver.Issue{
+			Message: "issue",
+			Start:   token.Position{Filename: filename, Line: start},
+			End:     token.Position{Filename: filename, Line: end},
+		}
+	}
+
+	inSection := issue(25, 25)
+	inSection.Section = &driver.Range{
+		Start: token.Position{Filename: filename, Line: 1},
+		End:   token.Position{Filename: filename, Line: 30},
+	}
+
+	related := issue(25, 25)
+	related.Related = []driver.Related{{
+		Start: token.Position{Filename: filename, Line: 4},
+		End:   token.Position{Filename: filename, Line: 4},
+	}}
+
+	require.Len(t, changes.Filter([]driver.Issue{issue(4, 4)}), 1)
+	require.Len(t, changes.Filter([]driver.Issue{issue(2, 5)}), 1)
+	require.Len(t, changes.Filter([]driver.Issue{issue(12, 0)}), 1)
+	require.Len(t, changes.Filter([]driver.Issue{issue(21, 21)}), 1)
+	require.Empty(t, changes.Filter([]driver.Issue{issue(13, 20)}))
+	require.Empty(t, changes.Filter([]driver.Issue{issue(22, 25)}))
+	require.Len(t, changes.Filter([]driver.Issue{inSection}), 1)
+	require.Len(t, changes.Filter([]driver.Issue{related}), 1)
+
+	other := issue(4, 4)
+	other.Start.Filename = filepath.Join(root, "b.go")
+	require.Empty(t, changes.Filter([]driver.Issue{other}))
+}
+
+func TestParsePatchMalformedHunk(t *testing.T) {
+	_, err := ParsePatch(t.TempDir(), strings.NewReader("+++ b/a.go\n@@ -1 +x @@\n"))
+	require.Error(t, err)
+}
+
+func TestFromRev(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{
+			"-c", "user.name=test",
+			"-c", "user.email=test@example.com",
+		}, args...)...)
+		cmd.Dir = root
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	write := func(name string, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o644))
+	}
+
+	write("a.go", "package a\n\nfunc a() {}\n\nfunc b() {}\n")
+	run("init", "-q")
+	run("config", "diff.noprefix", "true")
+	run("add", ".")
+	run("commit", "-q", "-m", "initial")
+	write("a.go", "package a\n\nfunc a() {}\n\nfunc b() {\n\tprintln()\n}\n")
+	write("new.go", "package a\n")
+
+	// Issues are reported with paths through the symlink, git resolves it.
+	link := filepath.Join(t.TempDir(), "link")
+	require.NoError(t, os.Symlink(root, link))
+
+	changes, err := FromRev(link, "HEAD")
+	require.NoError(t, err)
+
+	issue := func(name string, line int) driver.Issue {
+		return driver.Issue{Start: token.Position{Filename: filepath.Join(link, name), Line: line}}
+	}
+
+	require.Empty(t, changes.Filter([]driver.Issue{issue("a.go", 3)}))
+	require.Len(t, changes.Filter([]driver.Issue{issue("a.go", 6)}), 1)
+	require.Len(t, changes.Filter([]driver.Issue{issue("new.go", 1)}), 1)
+}
@@ -0,0 +1,99 @@
+// Package checkstyle converts issues of the NBS analyzers to the Checkstyle
+// XML format understood by Jenkins and TeamCity.
+//
+// Issues are grouped by file, the analyzer name and the diagnostic category
+// form the source of an error, e.g. "SeparatorAnalyzer.separator".
+package checkstyle
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Version is the Checkstyle version whose format is written.
+const Version = "8.0"
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Checkstyle struct {
+	XMLName xml.Name `xml:"checkstyle"`
+	Version string   `xml:"version,attr"`
+	Files   []File   `xml:"file"`
+}
+
+// New groups the issues by file in the order of the issues, paths inside the
+// root directory are made relative to it.
+func New(root string, issues []driver.Issue) *Checkstyle {
+	checkstyle := &Checkstyle{Version: Version}
+	indexes := make(map[string]int)
+	for _, issue := range issues {
+		name := driver.RelativePath(root, issue.Start.Filename)
+		index, ok := indexes[name]
+		if !ok {
+			index = len(checkstyle.Files)
+			indexes[name] = index
+			checkstyle.Files = append(checkstyle.Files, File{Name: name})
+		}
+
+		file := &checkstyle.Files[index]
+		file.Errors = append(file.Errors, newError(issue))
+	}
+
+	return checkstyle
+}
+
+func (c *Checkstyle) Write(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type File struct {
+	Name   string  `xml:"name,attr"`
+	Errors []Error `xml:"error"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Error struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func newError(issue driver.Issue) Error {
+	severity := SeverityError
+	if issue.IsWarning() {
+		severity = SeverityWarning
+	}
+
+	return Error{
+		Line:     issue.Start.Line,
+		Column:   issue.Start.Column,
+		Severity: severity,
+		Message:  issue.Message,
+		Source:   issue.ClassName(),
+	}
+}
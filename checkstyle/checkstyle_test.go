@@ -0,0 +1,52 @@
+package checkstyle
+
+import (
+	"bytes"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+func TestWrite(t *testing.T) {
+	root := t.TempDir()
+	issues := []driver.Issue{
+		{
+			Analyzer: "LineBreakAfterRbracket",
+			Category: "line_breaks",
+			Message:  "Line break after closing } is required.",
+			Start:    token.Position{Filename: filepath.Join(root, "a.go"), Line: 10, Column: 2},
+		},
+		{
+			Analyzer: "SeparatorAnalyzer",
+			Category: "separator",
+			Severity: rules.SeverityWarning,
+			Message:  "Separator at the end of the file is forbidden",
+			Start:    token.Position{Filename: filepath.Join(root, "a.go"), Line: 14, Column: 1},
+		},
+		{
+			Analyzer: "SeparatorAnalyzer",
+			Category: "separator",
+			Message:  "Empty section <&>",
+			Start:    token.Position{Filename: filepath.Join(root, "pkg", "b.go"), Line: 3, Column: 1},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, New(root, issues).Write(&buffer))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="8.0">
+  <file name="a.go">
+    <error line="10" column="2" severity="error" message="Line break after closing } is required." source="LineBreakAfterRbracket.line_breaks"></error>
+    <error line="14" column="1" severity="warning" message="Separator at the end of the file is forbidden" source="SeparatorAnalyzer.separator"></error>
+  </file>
+  <file name="pkg/b.go">
+    <error line="3" column="1" severity="error" message="Empty section &lt;&amp;&gt;" source="SeparatorAnalyzer.separator"></error>
+  </file>
+</checkstyle>
+`, buffer.String())
+}
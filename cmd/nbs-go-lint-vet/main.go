@@ -0,0 +1,29 @@
+// Command nbs-go-lint-vet runs the NBS analyzers as a vet tool, so the go
+// command takes care of build caching and test packages:
+//
+//	go vet -vettool=$(which nbs-go-lint-vet) ./...
+//
+// The analyzers are configured by the nearest .nbs-go-lint.yml in the package
+// directory or its parents.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func main() {
+	settings, err := nbs.FindSettings(".")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	unitchecker.Main(nbs.Analyzers(settings)...)
+}
@@ -0,0 +1,360 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+	"github.com/jkuradobery/nbs-go-lint/baseline"
+	"github.com/jkuradobery/nbs-go-lint/changes"
+	"github.com/jkuradobery/nbs-go-lint/checkstyle"
+	"github.com/jkuradobery/nbs-go-lint/driver"
+	"github.com/jkuradobery/nbs-go-lint/explain"
+	"github.com/jkuradobery/nbs-go-lint/junit"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/sarif"
+	"github.com/jkuradobery/nbs-go-lint/stats"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Exit codes are the same as the ones of multichecker.
+const (
+	exitSuccess = 0
+	exitFailure = 1
+	exitUsage   = 2
+	exitIssues  = 3
+)
+
+const (
+	formatText       = "text"
+	formatSARIF      = "sarif"
+	formatCheckstyle = "checkstyle"
+	formatJUnit      = "junit"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+var formats = []string{formatText, formatSARIF, formatCheckstyle, formatJUnit}
+
+var statsFormats = []string{formatTable, formatJSON}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func runCheck(args []string) int {
+	flags, analyzers, err := newFlagSet("check")
+	if err != nil {
+		return fail(err)
+	}
+
+	baselineFilename := flags.String(
+		"baseline",
+		baseline.DefaultFilename,
+		"report only issues which are not in the baseline file, it is ignored if it does not exist and the flag is not set",
+	)
+	format := flags.String("format", formatText, "output format: "+strings.Join(formats, ", "))
+	newFromRev := flags.String(
+		"new-from-rev",
+		"",
+		"report only issues in lines changed since the git revision, including uncommitted changes",
+	)
+	newFromPatch := flags.String(
+		"new-from-patch",
+		"",
+		"report only issues in lines changed by the unified diff file",
+	)
+	_ = flags.Parse(args)
+
+	if !slices.Contains(formats, *format) {
+		fmt.Fprintf(os.Stderr, "nbs-go-lint: unknown format %q\n", *format)
+		return exitUsage
+	}
+
+	if *newFromRev != "" && *newFromPatch != "" {
+		fmt.Fprintln(os.Stderr, "nbs-go-lint: -new-from-rev and -new-from-patch are mutually exclusive")
+		return exitUsage
+	}
+
+	issues, err := analyze(analyzers, flags.Args())
+	if err != nil {
+		return fail(err)
+	}
+
+	known, err := baseline.Read(*baselineFilename)
+	if errors.Is(err, fs.ErrNotExist) && !isFlagSet(flags, "baseline") {
+		known, err = &baseline.Baseline{}, nil
+	}
+
+	if err != nil {
+		return fail(err)
+	}
+
+	issues, err = known.Filter(filepath.Dir(*baselineFilename), issues)
+	if err != nil {
+		return fail(err)
+	}
+
+	changed, err := readChanges(*newFromRev, *newFromPatch)
+	if err != nil {
+		return fail(err)
+	}
+
+	if changed != nil {
+		issues = changed.Filter(issues)
+	}
+
+	if err := printIssues(*format, analyzers, issues); err != nil {
+		return fail(err)
+	}
+
+	if len(issues) != 0 {
+		return exitIssues
+	}
+
+	return exitSuccess
+}
+
+func runBaseline(args []string) int {
+	if len(args) == 0 || args[0] != "write" {
+		fmt.Fprintln(os.Stderr, "usage: nbs-go-lint baseline write [flags] [packages]")
+		return exitUsage
+	}
+
+	flags, analyzers, err := newFlagSet("baseline write")
+	if err != nil {
+		return fail(err)
+	}
+
+	baselineFilename := flags.String(
+		"baseline",
+		baseline.DefaultFilename,
+		"baseline file to write",
+	)
+	_ = flags.Parse(args[1:])
+
+	issues, err := analyze(analyzers, flags.Args())
+	if err != nil {
+		return fail(err)
+	}
+
+	known, err := baseline.New(filepath.Dir(*baselineFilename), issues)
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := known.Write(*baselineFilename); err != nil {
+		return fail(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d issues written to %s\n", len(issues), *baselineFilename)
+	return exitSuccess
+}
+
+func runExplain(args []string) int {
+	flags := flag.NewFlagSet("nbs-go-lint explain", flag.ExitOnError)
+	markdown := flags.Bool("markdown", false, "print the documentation of all rules as markdown")
+	_ = flags.Parse(args)
+
+	entries := explain.Entries(
+		nbs.Analyzers(nbs.Settings{}.WithOptInAnalyzers()),
+	)
+	if *markdown {
+		if err := explain.WriteMarkdown(os.Stdout, entries); err != nil {
+			return fail(err)
+		}
+
+		return exitSuccess
+	}
+
+	if flags.NArg() == 0 {
+		if err := explain.WriteList(os.Stdout, entries); err != nil {
+			return fail(err)
+		}
+
+		return exitSuccess
+	}
+
+	for i, query := range flags.Args() {
+		entry, ok := explain.Find(entries, query)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "nbs-go-lint: unknown rule %q\n", query)
+			return exitUsage
+		}
+
+		if i != 0 {
+			fmt.Println()
+		}
+
+		if err := explain.WriteText(os.Stdout, entry); err != nil {
+			return fail(err)
+		}
+	}
+
+	return exitSuccess
+}
+
+// runStats counts all issues regardless of the baseline, paths are relative
+// to the working directory.
+func runStats(args []string) int {
+	flags, analyzers, err := newFlagSet("stats")
+	if err != nil {
+		return fail(err)
+	}
+
+	format := flags.String("format", formatTable, "output format: "+strings.Join(statsFormats, ", "))
+	_ = flags.Parse(args)
+
+	if !slices.Contains(statsFormats, *format) {
+		fmt.Fprintf(os.Stderr, "nbs-go-lint: unknown format %q\n", *format)
+		return exitUsage
+	}
+
+	issues, err := analyze(analyzers, flags.Args())
+	if err != nil {
+		return fail(err)
+	}
+
+	counts := stats.New(".", issues)
+	switch *format {
+	case formatJSON:
+		err = counts.WriteJSON(os.Stdout)
+	default:
+		err = counts.WriteTable(os.Stdout)
+	}
+
+	if err != nil {
+		return fail(err)
+	}
+
+	return exitSuccess
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// newFlagSet registers the flags of the analyzers enabled by the settings file
+// with the analyzer name prefix, as multichecker does.
+func newFlagSet(name string) (*flag.FlagSet, []*analysis.Analyzer, error) {
+	settings, err := nbs.FindSettings(".")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flags := flag.NewFlagSet("nbs-go-lint "+name, flag.ExitOnError)
+	analyzers := nbs.Analyzers(settings)
+	for _, analyzer := range analyzers {
+		analyzer.Flags.VisitAll(func(f *flag.Flag) {
+			flags.Var(f.Value, analyzer.Name+"."+f.Name, f.Usage)
+		})
+	}
+
+	return flags, analyzers, nil
+}
+
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+
+	return set
+}
+
+func analyze(
+	analyzers []*analysis.Analyzer,
+	patterns []string,
+) ([]driver.Issue, error) {
+
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	pkgs, err := driver.Load("", patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.Run(analyzers, pkgs)
+}
+
+// readChanges returns nil if neither the revision nor the patch is given.
+// Paths in the patch are relative to the root of the git repository, or to
+// the working directory outside of repositories.
+func readChanges(rev string, patchFilename string) (*changes.Changes, error) {
+	if rev != "" {
+		return changes.FromRev(".", rev)
+	}
+
+	if patchFilename == "" {
+		return nil, nil
+	}
+
+	root, err := changes.RepositoryRoot(".")
+	if err != nil {
+		root = "."
+	}
+
+	patch, err := os.Open(patchFilename)
+	if err != nil {
+		return nil, err
+	}
+	defer patch.Close()
+
+	return changes.ParsePatch(root, patch)
+}
+
+// printIssues prints the issues to stdout, paths in reports are relative to
+// the working directory.
+func printIssues(
+	format string,
+	analyzers []*analysis.Analyzer,
+	issues []driver.Issue,
+) error {
+
+	switch format {
+	case formatSARIF:
+		log, err := sarif.New(".", analyzers, issues)
+		if err != nil {
+			return err
+		}
+
+		return log.Write(os.Stdout)
+	case formatCheckstyle:
+		return checkstyle.New(".", issues).Write(os.Stdout)
+	case formatJUnit:
+		return junit.New(".", issues).Write(os.Stdout)
+	default:
+		for _, issue := range issues {
+			message := issue.Message
+			if issue.IsWarning() {
+				message = rules.WarningPrefix + message
+			}
+
+			if issue.Code == "" {
+				fmt.Printf("%s: %s\n", issue.Start, message)
+				continue
+			}
+
+			fmt.Printf("%s: %s (%s)\n", issue.Start, message, issue.Code)
+		}
+
+		return nil
+	}
+}
+
+func fail(err error) int {
+	fmt.Fprintf(os.Stderr, "nbs-go-lint: %v\n", err)
+	return exitFailure
+}
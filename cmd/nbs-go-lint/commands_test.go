@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const multilineSignature = `package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+func sum(
+	a int,
+	b int,
+) int {
+
+	return a + b
+}
+`
+
+////////////////////////////////////////////////////////////////////////////////
+
+// chdir changes the working directory to a temporary module with the file,
+// the settings file is looked up from the working directory.
+func chdir(t *testing.T, source string) string {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example\n\ngo 1.23\n")
+	writeFile(t, filepath.Join(dir, "example.go"), source)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(wd))
+	})
+
+	return dir
+}
+
+func writeFile(t *testing.T, filename string, data string) {
+	require.NoError(t, os.WriteFile(filename, []byte(data), 0o644))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func TestRunCheckSettingsFile(t *testing.T) {
+	dir := chdir(t, multilineSignature)
+	require.Equal(t, exitSuccess, runCheck([]string{"./..."}))
+	require.Equal(t, exitSuccess, runStats([]string{"./..."}))
+
+	settings := filepath.Join(dir, nbs.SettingsFilename)
+	writeFile(t, settings, "single-line:\n  enabled: true\n")
+	require.Equal(t, exitIssues, runCheck([]string{"./..."}))
+
+	require.Equal(t, exitSuccess, runBaseline([]string{"write", "./..."}))
+	require.Equal(t, exitSuccess, runCheck([]string{"./..."}))
+
+	writeFile(t, settings, "single-line:\n  enable: true\n")
+	require.Equal(t, exitFailure, runCheck([]string{"./..."}))
+}
@@ -0,0 +1,57 @@
+// Command nbs-go-lint runs the NBS analyzers without golangci-lint:
+//
+//	nbs-go-lint [-fix] [-json] [-SeparatorAnalyzer.disable=all] ./...
+//
+// Run "nbs-go-lint help" for the list of analyzers and their flags.
+//
+// Subcommands use their own driver:
+//
+//	nbs-go-lint check [-baseline=file] [-format=text|sarif|checkstyle|junit]
+//		[-new-from-rev=ref | -new-from-patch=file] [analyzer flags] [packages]
+//	nbs-go-lint baseline write [-baseline=file] [analyzer flags] [packages]
+//	nbs-go-lint explain [-markdown] [code or ID...]
+//	nbs-go-lint stats [-format=table|json] [analyzer flags] [packages]
+//
+// "baseline write" records the current issues in the baseline file, "check"
+// reports only issues which are not recorded there, as text, a SARIF 2.1.0
+// log, Checkstyle XML or JUnit XML. With -new-from-rev or -new-from-patch it
+// reports only issues in lines changed since the git revision or by the
+// patch. "explain" prints the rationale and the examples of the rules with
+// the codes or IDs, e.g. NBS-SEP-004, or lists all rules. "stats" counts
+// all issues and the autofixable ones by rule, package and file.
+//
+// The analyzers are configured by the nearest .nbs-go-lint.yml in the working
+// directory or its parents, it has the format of the plugin settings.
+package main
+
+import (
+	"os"
+
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "check":
+			os.Exit(runCheck(os.Args[2:]))
+		case "baseline":
+			os.Exit(runBaseline(os.Args[2:]))
+		case "explain":
+			os.Exit(runExplain(os.Args[2:]))
+		case "stats":
+			os.Exit(runStats(os.Args[2:]))
+		}
+	}
+
+	settings, err := nbs.FindSettings(".")
+	if err != nil {
+		os.Exit(fail(err))
+	}
+
+	multichecker.Main(nbs.Analyzers(settings)...)
+}
@@ -0,0 +1,194 @@
+// Command nbsfmt formats Go files with gofmt and the suggested fixes of the
+// NBS analyzers:
+//
+//	nbsfmt [-w] [-l] [-d] [path ...]
+//
+// Directories are processed recursively, testdata and vendor directories and
+// directories starting with "." or "_" are skipped. Without paths the source
+// is read from the standard input. The analyzers are configured by the
+// nearest .nbs-go-lint.yml in the working directory or its parents.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/tools/go/analysis"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+	"github.com/jkuradobery/nbs-go-lint/nbsfmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+var (
+	write = flag.Bool("w", false, "write result to the source file instead of stdout")
+	list  = flag.Bool("l", false, "list files whose formatting differs from nbsfmt's")
+	diff  = flag.Bool("d", false, "display diffs instead of rewriting files")
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: nbsfmt [flags] [path ...]\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	settings, err := nbs.FindSettings(".")
+	if err != nil {
+		fatalf("%v", err)
+	}
+
+	analyzers := nbs.Analyzers(settings)
+	if flag.NArg() == 0 {
+		if *write {
+			fatalf("cannot use -w with standard input")
+		}
+
+		if err := processStdin(analyzers); err != nil {
+			fatalf("%v", err)
+		}
+
+		return
+	}
+
+	exitCode := 0
+	for _, path := range flag.Args() {
+		if err := processPath(path, analyzers); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exitCode = 2
+		}
+	}
+
+	os.Exit(exitCode)
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "nbsfmt: "+format+"\n", args...)
+	os.Exit(2)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func processStdin(analyzers []*analysis.Analyzer) error {
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	return processSource("<standard input>", src, analyzers)
+}
+
+func processPath(root string, analyzers []*analysis.Analyzer) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return processFile(root, analyzers)
+	}
+
+	return filepath.WalkDir(root, func(
+		path string,
+		entry fs.DirEntry,
+		err error,
+	) error {
+
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != root && isSkippedDir(entry.Name()) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(entry.Name(), ".go") ||
+			strings.HasPrefix(entry.Name(), ".") {
+
+			return nil
+		}
+
+		return processFile(path, analyzers)
+	})
+}
+
+func isSkippedDir(name string) bool {
+	return name == "testdata" ||
+		name == "vendor" ||
+		strings.HasPrefix(name, ".") ||
+		strings.HasPrefix(name, "_")
+}
+
+func processFile(filename string, analyzers []*analysis.Analyzer) error {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	return processSource(filename, src, analyzers)
+}
+
+func processSource(
+	filename string,
+	src []byte,
+	analyzers []*analysis.Analyzer,
+) error {
+
+	formatted, err := nbsfmt.Source(filename, src, analyzers)
+	if err != nil {
+		return err
+	}
+
+	changed := !bytes.Equal(src, formatted)
+	if *list && changed {
+		fmt.Println(filename)
+	}
+
+	if *write && changed {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(filename, formatted, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+	}
+
+	if *diff && changed {
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(src)),
+			B:        difflib.SplitLines(string(formatted)),
+			FromFile: filename + ".orig",
+			ToFile:   filename,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(text)
+	}
+
+	if !*list && !*write && !*diff {
+		_, err = os.Stdout.Write(formatted)
+	}
+
+	return err
+}
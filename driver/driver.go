@@ -0,0 +1,381 @@
+// Package driver runs analyzers on packages and collects their diagnostics
+// as issues with resolved positions, so the nbs-go-lint subcommands can
+// filter and print them in different formats.
+package driver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/separator_analyzer"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Issue is a diagnostic reported by an analyzer.
+type Issue struct {
+	Analyzer string
+	// Package is the import path of the analyzed package.
+	Package  string
+	Category string
+	// Code is the stable code of the rule, e.g. NBS-SEP-004, taken from the
+	// diagnostic URL. It is empty for analyzers of other repositories.
+	Code string
+	// Severity is the severity of the rule in the registry of the analyzer,
+	// issues of analyzers without registries are errors.
+	Severity rules.Severity
+	Message  string
+	Start    token.Position
+	End      token.Position
+	Fixes    []Fix
+	Related  []Related
+	// Section is the part of the file between separators for issues of
+	// section rules of SeparatorAnalyzer, which depend on all its lines.
+	Section *Range
+	// DeclarationHash is the hash of the text of the top-level declaration
+	// containing the issue, or of the line for issues between declarations.
+	DeclarationHash string
+}
+
+// Rule identifies the check which reported the issue.
+func (i Issue) Rule() string {
+	if i.Category == "" {
+		return i.Analyzer
+	}
+
+	return i.Analyzer + "/" + i.Category
+}
+
+// ClassName joins the analyzer name and the category with a dot, the way
+// Checkstyle and JUnit reports name the source of a failure.
+func (i Issue) ClassName() string {
+	if i.Category == "" {
+		return i.Analyzer
+	}
+
+	return i.Analyzer + "." + i.Category
+}
+
+// IsWarning reports whether the rule of the issue is downgraded to a warning.
+func (i Issue) IsWarning() bool {
+	return i.Severity == rules.SeverityWarning
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Fix is an analysis.SuggestedFix with resolved positions.
+type Fix struct {
+	Message string
+	Edits   []Edit
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Edit struct {
+	Start   token.Position
+	End     token.Position
+	NewText string
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Range is a range of resolved positions in a file.
+type Range struct {
+	Start token.Position
+	End   token.Position
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Related is an analysis.RelatedInformation with resolved positions.
+type Related struct {
+	Message string
+	Start   token.Position
+	End     token.Position
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Load loads the packages matching the patterns together with their tests
+// from source.
+func Load(dir string, patterns ...string) ([]*packages.Package, error) {
+	pkgs, err := packages.Load(
+		&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir, Tests: true},
+		patterns...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Test main packages are generated by the go command.
+	pkgs = slices.DeleteFunc(pkgs, func(pkg *packages.Package) bool {
+		return strings.HasSuffix(pkg.ID, ".test")
+	})
+
+	var errs []error
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err)
+		}
+	})
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return pkgs, nil
+}
+
+// Run analyzes the packages and returns issues sorted by position. Issues
+// of packages compiled with and without tests are reported once.
+func Run(
+	analyzers []*analysis.Analyzer,
+	pkgs []*packages.Package,
+) ([]Issue, error) {
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	var issues []Issue
+	seen := make(map[string]struct{})
+	sources := newSourceCache()
+	for _, action := range graph.Roots {
+		if action.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", action, action.Err))
+			continue
+		}
+
+		for _, diagnostic := range action.Diagnostics {
+			issue, err := newIssue(action, diagnostic, sources)
+			if err != nil {
+				return nil, err
+			}
+
+			key := fmt.Sprintf("%s %s %s", issue.Start, issue.Analyzer, issue.Message)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			issues = append(issues, issue)
+		}
+	}
+
+	slices.SortFunc(issues, compareIssues)
+	return issues, errors.Join(errs...)
+}
+
+// RelativePath returns the slash separated path of the file relative to the
+// directory, or the file name itself if the file is outside the directory.
+func RelativePath(dir string, filename string) string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return filename
+	}
+
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return filename
+	}
+
+	path, err := filepath.Rel(absDir, absFilename)
+	if err != nil || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return filename
+	}
+
+	return filepath.ToSlash(path)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func compareIssues(a, b Issue) int {
+	if a.Start.Filename != b.Start.Filename {
+		return strings.Compare(a.Start.Filename, b.Start.Filename)
+	}
+
+	if a.Start.Offset != b.Start.Offset {
+		return a.Start.Offset - b.Start.Offset
+	}
+
+	if a.Analyzer != b.Analyzer {
+		return strings.Compare(a.Analyzer, b.Analyzer)
+	}
+
+	return strings.Compare(a.Message, b.Message)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newIssue(
+	action *checker.Action,
+	diagnostic analysis.Diagnostic,
+	sources *sourceCache,
+) (Issue, error) {
+
+	fset := action.Package.Fset
+	end := diagnostic.End
+	if !end.IsValid() {
+		end = diagnostic.Pos
+	}
+
+	code := rules.CodeFromURL(diagnostic.URL)
+	issue := Issue{
+		Analyzer: action.Analyzer.Name,
+		Package:  action.Package.PkgPath,
+		Category: diagnostic.Category,
+		Code:     code,
+		Severity: severity(action.Analyzer, code),
+		Message:  diagnostic.Message,
+		Start:    fset.PositionFor(diagnostic.Pos, false),
+		End:      fset.PositionFor(end, false),
+	}
+
+	if issue.IsWarning() {
+		// The severity is kept by the issue, reports print it on their own.
+		issue.Message = strings.TrimPrefix(issue.Message, rules.WarningPrefix)
+	}
+
+	for _, suggestedFix := range diagnostic.SuggestedFixes {
+		fix := Fix{Message: suggestedFix.Message}
+		for _, textEdit := range suggestedFix.TextEdits {
+			editEnd := textEdit.End
+			if !editEnd.IsValid() {
+				editEnd = textEdit.Pos
+			}
+
+			fix.Edits = append(fix.Edits, Edit{
+				Start:   fset.PositionFor(textEdit.Pos, false),
+				End:     fset.PositionFor(editEnd, false),
+				NewText: string(textEdit.NewText),
+			})
+		}
+
+		issue.Fixes = append(issue.Fixes, fix)
+	}
+
+	for _, related := range diagnostic.Related {
+		relatedEnd := related.End
+		if !relatedEnd.IsValid() {
+			relatedEnd = related.Pos
+		}
+
+		issue.Related = append(issue.Related, Related{
+			Message: related.Message,
+			Start:   fset.PositionFor(related.Pos, false),
+			End:     fset.PositionFor(relatedEnd, false),
+		})
+	}
+
+	src, err := sources.read(issue.Start.Filename)
+	if err != nil {
+		return Issue{}, err
+	}
+
+	file := findFile(action.Package, issue.Start.Filename)
+	issue.DeclarationHash = declarationHash(fset, file, diagnostic.Pos, src)
+	if file != nil && separator_analyzer.IsSectionRule(code) {
+		start, end := separator_analyzer.Section(fset, file, src, diagnostic.Pos)
+		issue.Section = &Range{
+			Start: fset.PositionFor(start, false),
+			End:   fset.PositionFor(end, false),
+		}
+	}
+
+	return issue, nil
+}
+
+// severity looks the rule up in the registry configured by the flags of the
+// analyzer.
+func severity(analyzer *analysis.Analyzer, code string) rules.Severity {
+	registry := rules.FlagsRegistry(&analyzer.Flags)
+	if registry == nil || code == "" {
+		return rules.SeverityError
+	}
+
+	return registry.Severity(code)
+}
+
+func findFile(pkg *packages.Package, filename string) *ast.File {
+	for _, file := range pkg.Syntax {
+		if pkg.Fset.PositionFor(file.Pos(), false).Filename == filename {
+			return file
+		}
+	}
+
+	return nil
+}
+
+// declarationHash hashes the text of the enclosing declaration, so the hash
+// does not depend on the position of the declaration in the file.
+func declarationHash(
+	fset *token.FileSet,
+	file *ast.File,
+	pos token.Pos,
+	src []byte,
+) string {
+
+	tokenFile := fset.File(pos)
+	text := ""
+	if file != nil {
+		for _, declaration := range file.Decls {
+			if declaration.Pos() <= pos && pos < declaration.End() {
+				start := tokenFile.Offset(declaration.Pos())
+				text = string(src[start:tokenFile.Offset(declaration.End())])
+				break
+			}
+		}
+	}
+
+	if text == "" {
+		line := tokenFile.Line(pos)
+		start := tokenFile.Offset(tokenFile.LineStart(line))
+		end := len(src)
+		if line < tokenFile.LineCount() {
+			end = tokenFile.Offset(tokenFile.LineStart(line + 1))
+		}
+
+		text = strings.TrimSpace(string(src[start:end]))
+	}
+
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:8])
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type sourceCache struct {
+	sources map[string][]byte
+}
+
+func newSourceCache() *sourceCache {
+	return &sourceCache{sources: make(map[string][]byte)}
+}
+
+func (c *sourceCache) read(filename string) ([]byte, error) {
+	if src, ok := c.sources[filename]; ok {
+		return src, nil
+	}
+
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	c.sources[filename] = src
+	return src, nil
+}
@@ -0,0 +1,117 @@
+package driver
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/line_breaks_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/separator_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/testcommon"
+)
+
+func TestRun(t *testing.T) {
+	dir := filepath.Join(testcommon.TestdataDir(t), "src", "example")
+	pkgs, err := Load(dir, ".")
+	require.NoError(t, err)
+
+	analyzer := line_breaks_analyzer.LineBreakAfterRbracket()
+	issues, err := Run([]*analysis.Analyzer{analyzer}, pkgs)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+
+	issue := issues[0]
+	require.Equal(t, "LineBreakAfterRbracket", issue.Analyzer)
+	require.Equal(t, "LineBreakAfterRbracket/line_breaks", issue.Rule())
+	require.Equal(t, "LineBreakAfterRbracket.line_breaks", issue.ClassName())
+	require.Equal(t, line_breaks_analyzer.CodeLineBreakAfterRbrace, issue.Code)
+	require.NotEmpty(t, issue.Package)
+	require.False(t, issue.IsWarning())
+	require.Equal(t, filepath.Join(dir, "example.go"), issue.Start.Filename)
+	require.Equal(t, 10, issue.Start.Line)
+	require.Len(t, issue.Fixes, 1)
+	require.NotEmpty(t, issue.DeclarationHash)
+}
+
+func TestRunSeverity(t *testing.T) {
+	dir := filepath.Join(testcommon.TestdataDir(t), "src", "example")
+	pkgs, err := Load(dir, ".")
+	require.NoError(t, err)
+
+	registry := rules.NewRegistry(rules.Rule{ID: "rule", Code: "NBS-TST-001"})
+	analyzer := &analysis.Analyzer{
+		Name: "SeverityAnalyzer",
+		Doc:  "Reports a warning of the registry and an error with a warning-like message.",
+		Run: func(pass *analysis.Pass) (any, error) {
+			file := pass.Files[0]
+			registry.Report(pass, "rule", analysis.Diagnostic{
+				Pos:      file.Package,
+				Category: "severity",
+				Message:  "downgraded",
+			})
+			pass.Report(analysis.Diagnostic{
+				Pos:      file.Name.Pos(),
+				Category: "severity",
+				Message:  rules.WarningPrefix + "not downgraded",
+			})
+			return nil, nil
+		},
+	}
+	registry.RegisterFlags(&analyzer.Flags)
+	require.NoError(t, analyzer.Flags.Parse([]string{"-warn=rule"}))
+
+	issues, err := Run([]*analysis.Analyzer{analyzer}, pkgs)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	require.Equal(t, "downgraded", issues[0].Message)
+	require.Equal(t, "severity", issues[0].Category)
+	require.Equal(t, "NBS-TST-001", issues[0].Code)
+	require.True(t, issues[0].IsWarning())
+
+	require.Equal(t, rules.WarningPrefix+"not downgraded", issues[1].Message)
+	require.Equal(t, "severity", issues[1].Category)
+	require.False(t, issues[1].IsWarning())
+}
+
+func TestRunSection(t *testing.T) {
+	dir := filepath.Join(testcommon.TestdataDir(t), "src", "sections")
+	pkgs, err := Load(dir, ".")
+	require.NoError(t, err)
+
+	issues, err := Run(
+		[]*analysis.Analyzer{separator_analyzer.SeparatorAnalyzer()},
+		pkgs,
+	)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+
+	issue := issues[0]
+	require.Equal(t, separator_analyzer.CodeSectionEntities, issue.Code)
+	require.Empty(t, issue.Related)
+	require.NotNil(t, issue.Section)
+	require.Equal(t, 9, issue.Section.Start.Line)
+	require.Equal(t, 15, issue.Section.End.Line)
+}
+
+func TestDeclarationHash(t *testing.T) {
+	hash := func(src string, line int) string {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "example.go", src, 0)
+		require.NoError(t, err)
+		pos := fset.File(file.Pos()).LineStart(line)
+		return declarationHash(fset, file, pos, []byte(src))
+	}
+
+	original := "package example\n\nfunc a() {\n\tprintln()\n}\n"
+	moved := "package example\n\nfunc b() {}\n\nfunc a() {\n\tprintln()\n}\n"
+	changed := "package example\n\nfunc a() {\n\tprint()\n}\n"
+	require.Equal(t, hash(original, 4), hash(moved, 6))
+	require.NotEqual(t, hash(original, 4), hash(changed, 4))
+	require.NotEqual(t, hash(original, 4), hash(moved, 3))
+}
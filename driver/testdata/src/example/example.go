@@ -0,0 +1,16 @@
+package example
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func first() {
+	if true {
+		fmt.Println("first")
+	}
+	fmt.Println("done")
+}
+
+func second() {
+	fmt.Println("second")
+}
@@ -0,0 +1,10 @@
+package example
+
+import "testing"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func TestFirst(t *testing.T) {
+	first()
+	second()
+}
@@ -0,0 +1,15 @@
+package sections
+
+////////////////////////////////////////////////////////////////////////////////
+
+type first struct{}
+
+func (f first) method() {}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type second struct{}
+
+func (s second) method() {}
+
+func helper() {}
@@ -0,0 +1,218 @@
+// Package explain describes the rules of the NBS analyzers with their
+// rationale and the examples from the analyzer testdata, for
+// "nbs-go-lint explain" and the rules documentation.
+//
+// Examples are the packages used by the analyzer tests, "// want" comments
+// of the tests are removed from them.
+package explain
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/tools/go/analysis"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ExampleBad  = "bad"
+	ExampleGood = "good"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+var wantComment = regexp.MustCompile(`\s*// want .*$`)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Entry is a rule together with the name of the analyzer checking it.
+type Entry struct {
+	Analyzer string
+	Rule     rules.Rule
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// File is a source file of an example.
+type File struct {
+	Name string
+	Text string
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Entries returns the rules of the analyzers in the order of the analyzers.
+func Entries(analyzers []*analysis.Analyzer) []Entry {
+	var entries []Entry
+	for _, analyzer := range analyzers {
+		for _, rule := range nbs.Rules(analyzer) {
+			entries = append(entries, Entry{Analyzer: analyzer.Name, Rule: rule})
+		}
+	}
+
+	return entries
+}
+
+// Find looks the rule up by the ID or, case-insensitively, by the code.
+func Find(entries []Entry, query string) (Entry, bool) {
+	for _, entry := range entries {
+		if entry.Rule.ID == query || strings.EqualFold(entry.Rule.Code, query) {
+			return entry, true
+		}
+	}
+
+	return Entry{}, false
+}
+
+// WriteList writes a line with the code, the ID and the doc of every rule.
+func WriteList(w io.Writer, entries []Entry) error {
+	writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, entry := range entries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", entry.Rule.Code, entry.Rule.ID, entry.Rule.Doc)
+	}
+
+	return writer.Flush()
+}
+
+// WriteText writes the description of the rule with its examples as plain
+// text, example sources are indented by a tab.
+func WriteText(w io.Writer, entry Entry) error {
+	var builder strings.Builder
+	rule := entry.Rule
+	fmt.Fprintf(&builder, "%s %s (%s)\n\n", rule.Code, rule.ID, entry.Analyzer)
+	fmt.Fprintf(&builder, "%s\n\n", rule.Doc)
+	if rule.Rationale != "" {
+		fmt.Fprintf(&builder, "%s\n\n", rule.Rationale)
+	}
+
+	for _, name := range []string{ExampleBad, ExampleGood} {
+		files, err := Examples(rule, name)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			fmt.Fprintf(&builder, "%s example, %s:\n\n", title(name), file.Name)
+			for _, line := range strings.Split(strings.TrimSuffix(file.Text, "\n"), "\n") {
+				if line != "" {
+					line = "\t" + line
+				}
+
+				fmt.Fprintf(&builder, "%s\n", line)
+			}
+
+			builder.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&builder, "See %s\n", rules.URL(rule.Code))
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// WriteMarkdown writes the documentation of the rules, every rule is a
+// section with the code as the heading, so rules.URL points to it.
+func WriteMarkdown(w io.Writer, entries []Entry) error {
+	var builder strings.Builder
+	builder.WriteString("# Rules\n\n")
+	builder.WriteString("<!-- Generated by \"nbs-go-lint explain -markdown\", do not edit. -->\n\n")
+	builder.WriteString("Run `nbs-go-lint explain <code>` to read a rule in the terminal.\n")
+	builder.WriteString("Rules are configured by their IDs or codes.\n\n")
+	builder.WriteString("| Code | ID | Analyzer | Description |\n")
+	builder.WriteString("| --- | --- | --- | --- |\n")
+	for _, entry := range entries {
+		fmt.Fprintf(
+			&builder,
+			"| [%s](#%s) | `%s` | %s | %s |\n",
+			entry.Rule.Code,
+			strings.ToLower(entry.Rule.Code),
+			entry.Rule.ID,
+			entry.Analyzer,
+			entry.Rule.Doc,
+		)
+	}
+
+	for _, entry := range entries {
+		rule := entry.Rule
+		fmt.Fprintf(&builder, "\n## %s\n\n", rule.Code)
+		fmt.Fprintf(&builder, "ID `%s`, analyzer `%s`.\n\n", rule.ID, entry.Analyzer)
+		fmt.Fprintf(&builder, "%s\n", rule.Doc)
+		if rule.Rationale != "" {
+			fmt.Fprintf(&builder, "\n%s\n", rule.Rationale)
+		}
+
+		for _, name := range []string{ExampleBad, ExampleGood} {
+			files, err := Examples(rule, name)
+			if err != nil {
+				return err
+			}
+
+			for _, file := range files {
+				fmt.Fprintf(&builder, "\n%s example, `%s`:\n\n", title(name), file.Name)
+				fmt.Fprintf(&builder, "```go\n%s```\n", file.Text)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// Examples returns the files of the bad or the good example of the rule,
+// rules without examples have none.
+func Examples(rule rules.Rule, name string) ([]File, error) {
+	if rule.Examples == nil {
+		return nil, nil
+	}
+
+	names, err := fs.Glob(rule.Examples, name+"/*.go")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(names))
+	for _, filename := range names {
+		src, err := fs.ReadFile(rule.Examples, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, File{
+			Name: strings.TrimPrefix(filename, name+"/"),
+			Text: stripWantComments(string(src)),
+		})
+	}
+
+	return files, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// stripWantComments removes the expectations of analysistest, lines which
+// have nothing but an expectation are removed as a whole.
+func stripWantComments(src string) string {
+	lines := strings.Split(src, "\n")
+	result := lines[:0]
+	for _, line := range lines {
+		stripped := wantComment.ReplaceAllString(line, "")
+		if stripped == "" && line != "" {
+			continue
+		}
+
+		result = append(result, stripped)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func title(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
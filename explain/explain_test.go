@@ -0,0 +1,60 @@
+package explain
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/separator_analyzer"
+)
+
+func TestFind(t *testing.T) {
+	entries := Entries(nbs.Analyzers(nbs.Settings{}.WithOptInAnalyzers()))
+	entry, ok := Find(entries, "nbs-sep-004")
+	require.True(t, ok)
+	require.Equal(t, "SeparatorAnalyzer", entry.Analyzer)
+	require.Equal(t, separator_analyzer.RuleSeparatorOverCode, entry.Rule.ID)
+
+	entry, ok = Find(entries, separator_analyzer.RuleSeparatorOverCode)
+	require.True(t, ok)
+	require.Equal(t, separator_analyzer.CodeSeparatorOverCode, entry.Rule.Code)
+
+	_, ok = Find(entries, "NBS-SEP-999")
+	require.False(t, ok)
+}
+
+func TestWriteText(t *testing.T) {
+	entry, ok := Find(Entries(nbs.Analyzers(nbs.Settings{}.WithOptInAnalyzers())), "NBS-SEP-004")
+	require.True(t, ok)
+
+	var buffer bytes.Buffer
+	require.NoError(t, WriteText(&buffer, entry))
+	text := buffer.String()
+	require.Contains(t, text, "NBS-SEP-004 separator-over-code (SeparatorAnalyzer)\n")
+	require.Contains(t, text, entry.Rule.Rationale)
+	require.Contains(t, text, "Bad example, example.go:\n\n\tpackage example\n")
+	require.Contains(t, text, "Good example, example.go:\n\n\tpackage example\n")
+	require.Contains(t, text, rules.URL("NBS-SEP-004"))
+	require.NotContains(t, text, "// want")
+}
+
+func TestStripWantComments(t *testing.T) {
+	src := "package example\n\n\t// want +1 \"a\"\n\ta()\n} // want \"b\"\n"
+	require.Equal(t, "package example\n\n\ta()\n}\n", stripWantComments(src))
+}
+
+// TestDocumentation checks that docs/rules.md is regenerated with
+// "go run ./cmd/nbs-go-lint explain -markdown > docs/rules.md".
+func TestDocumentation(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteMarkdown(&buffer, Entries(nbs.Analyzers(nbs.Settings{}.WithOptInAnalyzers()))))
+
+	documentation, err := os.ReadFile(filepath.Join("..", "docs", "rules.md"))
+	require.NoError(t, err)
+	require.Equal(t, string(documentation), buffer.String())
+}
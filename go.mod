@@ -5,14 +5,14 @@ go 1.23.5
 require (
 	github.com/deckarep/golang-set/v2 v2.8.0
 	github.com/golangci/plugin-module-register v0.1.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/mod v0.24.0
 	golang.org/x/tools v0.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/mod v0.24.0 // indirect
 	golang.org/x/sync v0.14.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
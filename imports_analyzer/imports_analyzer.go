@@ -0,0 +1,355 @@
+package imports_analyzer
+
+import (
+	"embed"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	SingleDeclarationMessage = "Imports should be in a single parenthesized import declaration"
+	GroupsMessage            = "Imports should be grouped in the order: standard library, third-party, local"
+)
+
+const (
+	RuleSingleImportDeclaration = "single-import-declaration"
+	RuleImportGroups            = "import-groups"
+)
+
+const (
+	CodeSingleImportDeclaration = "NBS-IMP-001"
+	CodeImportGroups            = "NBS-IMP-002"
+)
+
+const Category = "imports"
+
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed testdata/src/explain
+var examples embed.FS
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Rules() []rules.Rule {
+	return rules.WithExamples(examples, []rules.Rule{
+		{
+			ID:   RuleSingleImportDeclaration,
+			Code: CodeSingleImportDeclaration,
+			Doc:  "Imports are in a single parenthesized import declaration.",
+			Rationale: "A single import block is the one place to look for the " +
+				"dependencies of a file and to keep them grouped.",
+		},
+		{
+			ID:   RuleImportGroups,
+			Code: CodeImportGroups,
+			Doc:  "Imports are grouped in the order: standard library, third-party, local.",
+			Rationale: "Grouped imports show at a glance what the file depends on " +
+				"outside of the project, and make merges of import blocks trivial.",
+		},
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Settings configure ImportGroupsAnalyzer, they are decoded from the
+// golangci-lint plugin configuration.
+type Settings struct {
+	rules.Toggle
+
+	// LocalPrefix is a comma separated list of import path prefixes of the
+	// project packages. The path of the module is used if it is empty, the
+	// groups are not checked if neither is known.
+	LocalPrefix string `json:"local-prefix,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func ImportGroupsAnalyzer(
+	options ...rules.Option[Settings],
+) *analysis.Analyzer {
+
+	settings := rules.ApplyOptions(options...)
+
+	analyzer := &analysis.Analyzer{
+		Name: "ImportGroupsAnalyzer",
+		Doc:  "Checks that imports are in a single declaration grouped by origin.",
+		URL:  rules.DocumentationURL,
+		Run: func(pass *analysis.Pass) (any, error) {
+			localPrefixes := splitPrefixes(settings.LocalPrefix)
+			// Drivers without module information leave the path empty.
+			if len(localPrefixes) == 0 &&
+				pass.Module != nil &&
+				pass.Module.Path != "" {
+
+				localPrefixes = []string{pass.Module.Path}
+			}
+
+			for _, file := range pass.Files {
+				checkImports(pass, file, localPrefixes)
+			}
+
+			return nil, nil
+		},
+	}
+	analyzer.Flags.StringVar(
+		&settings.LocalPrefix,
+		"local-prefix",
+		settings.LocalPrefix,
+		"comma separated list of import path prefixes of the project packages",
+	)
+
+	return analyzer
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// importKind is the origin of the imported package, groups are ordered by it.
+type importKind int
+
+const (
+	standardImport importKind = iota
+	thirdPartyImport
+	localImport
+)
+
+func splitPrefixes(value string) []string {
+	var prefixes []string
+	for _, prefix := range strings.Split(value, ",") {
+		prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "/")
+		if prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return prefixes
+}
+
+func importPath(spec *ast.ImportSpec) string {
+	path, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return spec.Path.Value
+	}
+
+	return path
+}
+
+// kindOf treats paths without a dot in the first element as standard
+// library ones, the same way goimports does.
+func kindOf(path string, localPrefixes []string) importKind {
+	for _, prefix := range localPrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return localImport
+		}
+	}
+
+	firstElement, _, _ := strings.Cut(path, "/")
+	if !strings.Contains(firstElement, ".") {
+		return standardImport
+	}
+
+	return thirdPartyImport
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func checkImports(
+	pass *analysis.Pass,
+	file *ast.File,
+	localPrefixes []string,
+) {
+
+	var declarations []*ast.GenDecl
+	for _, declaration := range file.Decls {
+		if genDecl, ok := declaration.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			declarations = append(declarations, genDecl)
+		}
+	}
+
+	if len(declarations) == 0 {
+		return
+	}
+
+	for _, spec := range file.Imports {
+		if importPath(spec) == "C" {
+			// Cgo preamble requires its own import declaration.
+			return
+		}
+	}
+
+	code, message := "", ""
+	switch {
+	case len(declarations) > 1 || !declarations[0].Lparen.IsValid():
+		code, message = CodeSingleImportDeclaration, SingleDeclarationMessage
+	case len(localPrefixes) == 0:
+		// Local imports can not be told from third-party ones without the
+		// module, e.g. in GOPATH mode.
+		return
+	case !isGroupedByKind(pass.Fset, declarations[0], localPrefixes):
+		code, message = CodeImportGroups, GroupsMessage
+	default:
+		return
+	}
+
+	var fixes []analysis.SuggestedFix
+	if len(localPrefixes) > 0 {
+		// The fix groups imports by kind.
+		fixes = rewriteImportsFix(file, declarations, localPrefixes)
+	}
+
+	first := declarations[0]
+	last := declarations[len(declarations)-1]
+	pass.Report(analysis.Diagnostic{
+		Pos:            first.Pos(),
+		End:            last.End(),
+		Category:       Category,
+		URL:            rules.URL(code),
+		Message:        message,
+		SuggestedFixes: fixes,
+	})
+}
+
+// isGroupedByKind reports whether every group of imports separated by empty
+// lines has a single kind and the groups are ordered by kind.
+func isGroupedByKind(
+	fset *token.FileSet,
+	declaration *ast.GenDecl,
+	localPrefixes []string,
+) bool {
+
+	previousKind := importKind(-1)
+	previousEndLine := 0
+	for index, spec := range declaration.Specs {
+		importSpec := spec.(*ast.ImportSpec)
+		start := importSpec.Pos()
+		if importSpec.Doc != nil {
+			start = importSpec.Doc.Pos()
+		}
+
+		kind := kindOf(importPath(importSpec), localPrefixes)
+		startLine := fset.PositionFor(start, false).Line
+		isNewGroup := index > 0 && startLine > previousEndLine+1
+		switch {
+		case index == 0:
+		case isNewGroup && kind <= previousKind:
+			return false
+		case !isNewGroup && kind != previousKind:
+			return false
+		}
+
+		previousKind = kind
+		previousEndLine = fset.PositionFor(importSpec.End(), false).Line
+	}
+
+	return true
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// rewriteImportsFix replaces all import declarations with a single one.
+// Imports are sorted within groups as gofmt does, the doc and line comments
+// of imports are kept. There is no fix if other comments are between the
+// declarations.
+func rewriteImportsFix(
+	file *ast.File,
+	declarations []*ast.GenDecl,
+	localPrefixes []string,
+) []analysis.SuggestedFix {
+
+	start := declarations[0].Pos()
+	last := declarations[len(declarations)-1]
+	end := last.End()
+	if !last.Lparen.IsValid() {
+		// Line comment of the import is after the declaration.
+		if comment := last.Specs[0].(*ast.ImportSpec).Comment; comment != nil {
+			end = comment.End()
+		}
+	}
+
+	attached := make(map[*ast.CommentGroup]struct{})
+	groups := make([][]*ast.ImportSpec, localImport+1)
+	for _, declaration := range declarations {
+		if declaration != declarations[0] && declaration.Doc != nil {
+			return nil
+		}
+
+		for _, spec := range declaration.Specs {
+			importSpec := spec.(*ast.ImportSpec)
+			attached[importSpec.Doc] = struct{}{}
+			attached[importSpec.Comment] = struct{}{}
+
+			kind := kindOf(importPath(importSpec), localPrefixes)
+			groups[kind] = append(groups[kind], importSpec)
+		}
+	}
+
+	for _, group := range file.Comments {
+		if group.Pos() < start || group.End() > end {
+			continue
+		}
+
+		if _, ok := attached[group]; !ok {
+			return nil
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("import (\n")
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+
+		if builder.Len() > len("import (\n") {
+			builder.WriteString("\n")
+		}
+
+		slices.SortStableFunc(group, func(a, b *ast.ImportSpec) int {
+			return strings.Compare(importPath(a), importPath(b))
+		})
+		for _, spec := range group {
+			writeImportSpec(&builder, spec)
+		}
+	}
+
+	builder.WriteString(")")
+
+	return []analysis.SuggestedFix{{
+		Message: "Rewrite imports",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     start,
+			End:     end,
+			NewText: []byte(builder.String()),
+		}},
+	}}
+}
+
+func writeImportSpec(builder *strings.Builder, spec *ast.ImportSpec) {
+	if spec.Doc != nil {
+		for _, comment := range spec.Doc.List {
+			builder.WriteString("\t" + comment.Text + "\n")
+		}
+	}
+
+	builder.WriteString("\t")
+	if spec.Name != nil {
+		builder.WriteString(spec.Name.Name + " ")
+	}
+
+	builder.WriteString(spec.Path.Value)
+	if spec.Comment != nil {
+		for _, comment := range spec.Comment.List {
+			builder.WriteString(" " + comment.Text)
+		}
+	}
+
+	builder.WriteString("\n")
+}
@@ -0,0 +1,43 @@
+package imports_analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/testcommon"
+)
+
+func TestImportGroupsAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(
+		t,
+		testcommon.TestdataDir(t),
+		ImportGroupsAnalyzer(rules.WithSettings(Settings{
+			LocalPrefix: "example",
+		})),
+		"example/",
+	)
+}
+
+// TestImportGroupsAnalyzerWithoutModule checks that groups are not checked
+// if local imports are unknown, as testdata is loaded in GOPATH mode.
+func TestImportGroupsAnalyzerWithoutModule(t *testing.T) {
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		ImportGroupsAnalyzer(),
+		"nomodule/",
+	)
+}
+
+func TestImportGroupsAnalyzerExamples(t *testing.T) {
+	testcommon.RunExamples(
+		t,
+		testcommon.TestdataDir(t),
+		ImportGroupsAnalyzer(rules.WithSettings(Settings{
+			LocalPrefix: "example",
+		})),
+		Rules(),
+	)
+}
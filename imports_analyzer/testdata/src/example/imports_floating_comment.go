@@ -0,0 +1,16 @@
+package example
+
+// want +1 "Imports should be grouped in the order: standard library, third-party, local"
+import (
+	"github.com/third/party"
+
+	// Floating comments are not moved, so there is no fix.
+
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func ImportsFloatingComment() string {
+	return strings.ToLower(party.Party())
+}
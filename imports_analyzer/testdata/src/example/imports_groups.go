@@ -0,0 +1,18 @@
+package example
+
+// want +1 "Imports should be grouped in the order: standard library, third-party, local"
+import (
+	"example/local"
+	"fmt"
+	third "github.com/third/party"
+
+	// Other is documented.
+	"github.com/third/other"
+	"os"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func ImportsGroups() {
+	fmt.Fprintln(os.Stdout, third.Party(), other.Other(), local.Local())
+}
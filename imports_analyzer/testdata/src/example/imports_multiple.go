@@ -0,0 +1,12 @@
+package example
+
+// want +1 "Imports should be in a single parenthesized import declaration"
+import "github.com/third/party"
+
+import "fmt" // line comment
+
+////////////////////////////////////////////////////////////////////////////////
+
+func ImportsMultiple() {
+	fmt.Println(party.Party())
+}
@@ -0,0 +1,16 @@
+package example
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/third/party"
+
+	"example/local"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func ImportsOk() string {
+	return fmt.Sprint(strings.ToUpper(party.Party()), local.Local())
+}
@@ -0,0 +1,10 @@
+package example
+
+// want +1 "Imports should be in a single parenthesized import declaration"
+import "strings"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func ImportsSingle() string {
+	return strings.ToLower("single")
+}
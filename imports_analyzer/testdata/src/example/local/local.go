@@ -0,0 +1,7 @@
+package local
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Local() string {
+	return "local"
+}
@@ -0,0 +1,11 @@
+package example
+
+// want +1 "Imports should be in a single parenthesized import declaration"
+import "fmt"
+import "os"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Hello() {
+	fmt.Fprintln(os.Stdout, "hello")
+}
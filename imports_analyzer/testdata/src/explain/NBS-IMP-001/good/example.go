@@ -0,0 +1,12 @@
+package example
+
+import (
+	"fmt"
+	"os"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Hello() {
+	fmt.Fprintln(os.Stdout, "hello")
+}
@@ -0,0 +1,15 @@
+package example
+
+// want +1 "Imports should be grouped in the order: standard library, third-party, local"
+import (
+	"example/local"
+	"fmt"
+	"github.com/third/party"
+	"os"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Hello() {
+	fmt.Fprintln(os.Stdout, party.Party(), local.Local())
+}
@@ -0,0 +1,16 @@
+package example
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/third/party"
+
+	"example/local"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Hello() {
+	fmt.Fprintln(os.Stdout, party.Party(), local.Local())
+}
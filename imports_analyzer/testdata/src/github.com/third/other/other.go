@@ -0,0 +1,7 @@
+package other
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Other() string {
+	return "other"
+}
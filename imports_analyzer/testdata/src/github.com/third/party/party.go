@@ -0,0 +1,7 @@
+package party
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Party() string {
+	return "party"
+}
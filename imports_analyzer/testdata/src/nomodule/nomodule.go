@@ -0,0 +1,15 @@
+package nomodule
+
+import (
+	"fmt"
+
+	"github.com/third/party"
+
+	"nomodule/local"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func NoModule() string {
+	return fmt.Sprint(party.Party(), local.Local())
+}
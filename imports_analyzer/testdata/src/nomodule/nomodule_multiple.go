@@ -0,0 +1,12 @@
+package nomodule
+
+// want +1 "Imports should be in a single parenthesized import declaration"
+import "github.com/third/party"
+
+import "strings"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func NoModuleMultiple() string {
+	return strings.ToUpper(party.Party())
+}
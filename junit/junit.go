@@ -0,0 +1,156 @@
+// Package junit converts issues of the NBS analyzers to JUnit XML, so CI
+// servers show them as failed tests.
+//
+// Every package is a test suite and every rule violated in the package is a
+// failed test case. The class name of a test case joins the analyzer name
+// and the diagnostic category, e.g. "SeparatorAnalyzer.separator".
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	FailureError   = "error"
+	FailureWarning = "warning"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+// New groups the issues by package and rule, paths in failure texts are made
+// relative to the root directory if they are inside it.
+func New(root string, issues []driver.Issue) *TestSuites {
+	suites := make(map[string]map[string][]driver.Issue)
+	for _, issue := range issues {
+		if _, ok := suites[issue.Package]; !ok {
+			suites[issue.Package] = make(map[string][]driver.Issue)
+		}
+
+		rule := ruleCode(issue)
+		suites[issue.Package][rule] = append(suites[issue.Package][rule], issue)
+	}
+
+	result := &TestSuites{Name: "nbs-go-lint"}
+	for _, pkg := range sortedKeys(suites) {
+		suite := TestSuite{Name: pkg}
+		for _, rule := range sortedKeys(suites[pkg]) {
+			suite.Cases = append(suite.Cases, newTestCase(root, rule, suites[pkg][rule]))
+		}
+
+		suite.Tests = len(suite.Cases)
+		suite.Failures = len(suite.Cases)
+		result.Tests += suite.Tests
+		result.Failures += suite.Failures
+		result.Suites = append(result.Suites, suite)
+	}
+
+	return result
+}
+
+func (s *TestSuites) Write(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type TestSuite struct {
+	Name     string     `xml:"name,attr"`
+	Tests    int        `xml:"tests,attr"`
+	Failures int        `xml:"failures,attr"`
+	Cases    []TestCase `xml:"testcase"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+}
+
+// newTestCase lists the issues of the rule in the failure text, one per line.
+// The failure is a warning only if all issues are warnings.
+func newTestCase(root string, rule string, issues []driver.Issue) TestCase {
+	failureType := FailureWarning
+	lines := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if !issue.IsWarning() {
+			failureType = FailureError
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"%s:%d:%d: %s",
+			driver.RelativePath(root, issue.Start.Filename),
+			issue.Start.Line,
+			issue.Start.Column,
+			issue.Message,
+		))
+	}
+
+	return TestCase{
+		Name:      rule,
+		ClassName: issues[0].ClassName(),
+		Failure: &Failure{
+			Message: fmt.Sprintf("%d issue(s) of %s", len(issues), rule),
+			Type:    failureType,
+			Text:    strings.Join(lines, "\n"),
+		},
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ruleCode falls back to the analyzer and the category for diagnostics
+// without a rule code.
+func ruleCode(issue driver.Issue) string {
+	if issue.Code != "" {
+		return issue.Code
+	}
+
+	return issue.Rule()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
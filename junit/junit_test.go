@@ -0,0 +1,71 @@
+package junit
+
+import (
+	"bytes"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+func TestWrite(t *testing.T) {
+	root := t.TempDir()
+	issues := []driver.Issue{
+		{
+			Analyzer: "SeparatorAnalyzer",
+			Category: "separator",
+			Package:  "example/b",
+			Code:     "NBS-SEP-006",
+			Severity: rules.SeverityWarning,
+			Message:  "Empty section",
+			Start:    token.Position{Filename: filepath.Join(root, "b", "b.go"), Line: 3, Column: 1},
+		},
+		{
+			Analyzer: "LineBreakAfterRbracket",
+			Category: "line_breaks",
+			Package:  "example/a",
+			Code:     "NBS-LBR-002",
+			Message:  "Line break after closing } is required.",
+			Start:    token.Position{Filename: filepath.Join(root, "a", "a.go"), Line: 10, Column: 2},
+		},
+		{
+			Analyzer: "LineBreakAfterRbracket",
+			Category: "line_breaks",
+			Package:  "example/a",
+			Code:     "NBS-LBR-002",
+			Message:  "Line break after closing } is required.",
+			Start:    token.Position{Filename: filepath.Join(root, "a", "a.go"), Line: 20, Column: 3},
+		},
+		{
+			Analyzer: "UnusedSuppressionAnalyzer",
+			Category: "suppression",
+			Package:  "example/a",
+			Message:  "Unused suppression of separator",
+			Start:    token.Position{Filename: filepath.Join(root, "a", "a.go"), Line: 30, Column: 1},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, New(root, issues).Write(&buffer))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="nbs-go-lint" tests="3" failures="3">
+  <testsuite name="example/a" tests="2" failures="2">
+    <testcase name="NBS-LBR-002" classname="LineBreakAfterRbracket.line_breaks">
+      <failure message="2 issue(s) of NBS-LBR-002" type="error">a/a.go:10:2: Line break after closing } is required.&#xA;a/a.go:20:3: Line break after closing } is required.</failure>
+    </testcase>
+    <testcase name="UnusedSuppressionAnalyzer/suppression" classname="UnusedSuppressionAnalyzer.suppression">
+      <failure message="1 issue(s) of UnusedSuppressionAnalyzer/suppression" type="error">a/a.go:30:1: Unused suppression of separator</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="example/b" tests="1" failures="1">
+    <testcase name="NBS-SEP-006" classname="SeparatorAnalyzer.separator">
+      <failure message="1 issue(s) of NBS-SEP-006" type="warning">b/b.go:3:1: Empty section</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buffer.String())
+}
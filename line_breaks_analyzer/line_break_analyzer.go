@@ -1,29 +1,105 @@
 package line_breaks_analyzer
 
 import (
+	"embed"
 	set "github.com/deckarep/golang-set/v2"
 	"go/ast"
+	"go/token"
 	"log"
 	"regexp"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	RuleLineBreakBeforeRbrace = "line-break-before-rbrace"
+	RuleLineBreakAfterRbrace  = "line-break-after-rbrace"
+	RuleLineBreakBeforeDefer  = "line-break-before-defer"
+	RuleLineBreakBeforeRparen = "line-break-before-rparen"
 )
 
-// //////////////////////////////////////////////////////////////////////////////
+const (
+	CodeLineBreakBeforeRbrace = "NBS-LBR-001"
+	CodeLineBreakAfterRbrace  = "NBS-LBR-002"
+	CodeLineBreakBeforeDefer  = "NBS-LBR-003"
+	CodeLineBreakBeforeRparen = "NBS-LBR-004"
+)
+
+const Category = "line_breaks"
+
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed testdata/src/explain
+var examples embed.FS
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Rules() []rules.Rule {
+	return rules.WithExamples(examples, []rules.Rule{
+		{
+			ID:   RuleLineBreakBeforeRbrace,
+			Code: CodeLineBreakBeforeRbrace,
+			Doc:  "Empty lines before the closing } of a block are forbidden.",
+			Rationale: "An empty line before } looks like the block continues below " +
+				"and wastes vertical space without separating anything.",
+		},
+		{
+			ID:   RuleLineBreakAfterRbrace,
+			Code: CodeLineBreakAfterRbrace,
+			Doc:  "An empty line is required after the closing } of a block, except before defer and other closing brackets.",
+			Rationale: "The empty line makes the end of a nested block visible, so the " +
+				"statement after it is not read as a part of the block.",
+		},
+		{
+			ID:   RuleLineBreakBeforeDefer,
+			Code: CodeLineBreakBeforeDefer,
+			Doc:  "The defer statement is pressed against the block above it without empty lines.",
+			Rationale: "A defer usually releases what the block above it acquired, " +
+				"keeping them together shows that they belong to each other.",
+		},
+		{
+			ID:   RuleLineBreakBeforeRparen,
+			Code: CodeLineBreakBeforeRparen,
+			Doc:  "The closing ) of a call with arguments split over several lines is on its own line.",
+			Rationale: "With ) on its own line every argument is on a line of its own, " +
+				"so adding or removing the last argument changes a single line.",
+		},
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Settings configure LineBreakAfterRbracket, they are decoded from the
+// golangci-lint plugin configuration.
+type Settings struct {
+	rules.Toggle
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// LineBreakAfterRbracket ignores the options, its settings only switch it
+// on and off.
+func LineBreakAfterRbracket(
+	options ...rules.Option[Settings],
+) *analysis.Analyzer {
 
-func LineBreakAfterRbracket() *analysis.Analyzer {
 	return &analysis.Analyzer{
 		Name: "LineBreakAfterRbracket",
 		Doc:  "Checks for line breaks after code block closures.",
+		URL:  rules.DocumentationURL,
 		Run: func(pass *analysis.Pass) (any, error) {
 			for _, file := range pass.Files {
 				fset := pass.Fset
-				data, err := pass.ReadFile(fset.Position(file.Pos()).Filename)
+				data, err := pass.ReadFile(fset.PositionFor(file.Pos(), false).Filename)
 				if err != nil {
 					log.Fatalf(
 						"Error reading file %v: %v",
-						fset.Position(file.Pos()).Filename,
+						fset.PositionFor(file.Pos(), false).Filename,
 						err,
 					)
 				}
@@ -34,12 +110,12 @@ func LineBreakAfterRbracket() *analysis.Analyzer {
 					if funcDecl, ok := n.(*ast.FuncDecl); ok {
 						// We have a convention that line numbers start from 0,
 						// and it should be maintained throughout the linter.
-						line := fset.Position(funcDecl.Body.Lbrace).Line - 1
+						line := fset.PositionFor(funcDecl.Body.Lbrace, false).Line - 1
 						functionBodyLbracketsByLine.Add(line)
 					}
 
 					if funcLit, ok := n.(*ast.FuncLit); ok {
-						line := fset.Position(funcLit.Body.Lbrace).Line - 1
+						line := fset.PositionFor(funcLit.Body.Lbrace, false).Line - 1
 						functionBodyLbracketsByLine.Add(line)
 					}
 
@@ -67,6 +143,10 @@ func LineBreakAfterRbracket() *analysis.Analyzer {
 							functionBodyLbracketsByLine,
 						)
 					}
+
+					if callExpression, ok := node.(*ast.CallExpr); ok {
+						checkLineBreakBeforeRparen(pass, callExpression, lines)
+					}
 					return true
 				})
 			}
@@ -84,8 +164,8 @@ func checkNoLineBreakBeforeRbracket(
 	lines []string,
 ) {
 	rbrace := blockStatement.Rbrace
-	lbraceLine := pass.Fset.Position(blockStatement.Lbrace).Line
-	rbraceLine := pass.Fset.Position(rbrace).Line
+	lbraceLine := pass.Fset.PositionFor(blockStatement.Lbrace, false).Line
+	rbraceLine := pass.Fset.PositionFor(rbrace, false).Line
 	if rbraceLine-lbraceLine < 2 {
 		return
 	}
@@ -105,8 +185,15 @@ func checkNoLineBreakBeforeRbracket(
 	pass.Report(analysis.Diagnostic{
 		Pos:      rbrace,
 		End:      0,
-		Category: "line_breaks",
+		Category: Category,
+		URL:      rules.URL(CodeLineBreakBeforeRbrace),
 		Message:  "Line break before closing } is not allowed.",
+		SuggestedFixes: removeEmptyLinesBeforeFix(
+			pass,
+			rbrace,
+			lines,
+			"Remove line break before closing }",
+		),
 	})
 }
 
@@ -117,8 +204,9 @@ func checkLineBreakAfterRbracket(
 ) {
 
 	rbrace := blockStatement.Rbrace
-	rbracePosition := pass.Fset.Position(
+	rbracePosition := pass.Fset.PositionFor(
 		rbrace,
+		false,
 	)
 	nextLineIndex := rbracePosition.Line //  .Line indexing starts from 1
 	if nextLineIndex >= len(lines) {
@@ -140,7 +228,9 @@ func checkLineBreakAfterRbracket(
 		)
 		beforeComment := strings.Split(afterBracket, `//`)[0]
 		beforeComment = regexp.MustCompile(`/\*.+\*/`).ReplaceAllString(
-			beforeComment, "")
+			beforeComment,
+			"",
+		)
 		if regexp.MustCompile(".*[,})].*").MatchString(beforeComment) {
 			return
 		}
@@ -153,8 +243,15 @@ func checkLineBreakAfterRbracket(
 	pass.Report(analysis.Diagnostic{
 		Pos:      rbrace,
 		End:      0,
-		Category: "line_breaks",
+		Category: Category,
+		URL:      rules.URL(CodeLineBreakAfterRbrace),
 		Message:  "Line break after closing } is required.",
+		SuggestedFixes: insertEmptyLineAfterFix(
+			pass,
+			rbrace,
+			lines,
+			"Insert line break after closing }",
+		),
 	})
 }
 
@@ -165,7 +262,7 @@ func checkNoNewLineBeforeDeferStatement(
 	functionBodyLbracketsByLine set.Set[int],
 ) {
 	deferStmtPos := deferStatement.Pos()
-	previousLineIndex := pass.Fset.Position(deferStmtPos).Line - 2
+	previousLineIndex := pass.Fset.PositionFor(deferStmtPos, false).Line - 2
 	// .Line indexing starts from 1,
 	// so we need to subtract 2 to get the previous line
 	if previousLineIndex < 0 || previousLineIndex >= len(lines) {
@@ -185,7 +282,169 @@ func checkNoNewLineBeforeDeferStatement(
 	pass.Report(analysis.Diagnostic{
 		Pos:      deferStmtPos,
 		End:      0,
-		Category: "line_breaks",
+		Category: Category,
+		URL:      rules.URL(CodeLineBreakBeforeDefer),
 		Message:  "Line break before 'defer' statement is not allowed.",
+		SuggestedFixes: removeEmptyLinesBeforeFix(
+			pass,
+			deferStmtPos,
+			lines,
+			"Remove line break before 'defer' statement",
+		),
 	})
 }
+
+// checkLineBreakBeforeRparen requires ) of a call on its own line if the
+// arguments are split over several lines. Calls with all arguments starting
+// on the line of ( are not multiline, e.g. f(a, func() {\n...\n}).
+func checkLineBreakBeforeRparen(
+	pass *analysis.Pass,
+	callExpression *ast.CallExpr,
+	lines []string,
+) {
+
+	if len(callExpression.Args) == 0 {
+		return
+	}
+
+	fset := pass.Fset
+	lparenLine := fset.PositionFor(callExpression.Lparen, false).Line
+	isMultiline := false
+	for _, argument := range callExpression.Args {
+		if fset.PositionFor(argument.Pos(), false).Line > lparenLine {
+			isMultiline = true
+			break
+		}
+	}
+
+	if !isMultiline {
+		return
+	}
+
+	argumentsEnd := callExpression.Args[len(callExpression.Args)-1].End()
+	if callExpression.Ellipsis.IsValid() {
+		argumentsEnd = callExpression.Ellipsis + token.Pos(len("..."))
+	}
+
+	rparen := callExpression.Rparen
+	argumentsEndLine := fset.PositionFor(argumentsEnd, false).Line
+	if fset.PositionFor(rparen, false).Line > argumentsEndLine {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      rparen,
+		End:      0,
+		Category: Category,
+		URL:      rules.URL(CodeLineBreakBeforeRparen),
+		Message:  "Line break before ) in multiline call is required.",
+		SuggestedFixes: insertLineBreakBeforeRparenFix(
+			pass,
+			callExpression,
+			argumentsEnd,
+			lines,
+		),
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Fixes work with lines as they are in the file, so //line directives do not
+// affect them. Only empty lines are removed or inserted, comments are kept.
+
+// removeEmptyLinesBeforeFix removes all empty lines between the line of pos
+// and the previous non-empty line.
+func removeEmptyLinesBeforeFix(
+	pass *analysis.Pass,
+	pos token.Pos,
+	lines []string,
+	message string,
+) []analysis.SuggestedFix {
+
+	tokenFile := pass.Fset.File(pos)
+	line := tokenFile.PositionFor(pos, false).Line
+	firstEmptyLine := line
+	for firstEmptyLine > 1 &&
+		strings.TrimSpace(lines[firstEmptyLine-2]) == "" {
+
+		firstEmptyLine--
+	}
+
+	if firstEmptyLine == line {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: message,
+		TextEdits: []analysis.TextEdit{{
+			Pos: tokenFile.LineStart(firstEmptyLine),
+			End: tokenFile.LineStart(line),
+		}},
+	}}
+}
+
+// insertEmptyLineAfterFix inserts an empty line after the line of pos if
+// only a comment follows pos on its line, e.g. there is no fix for "} else {".
+func insertEmptyLineAfterFix(
+	pass *analysis.Pass,
+	pos token.Pos,
+	lines []string,
+	message string,
+) []analysis.SuggestedFix {
+
+	tokenFile := pass.Fset.File(pos)
+	position := tokenFile.PositionFor(pos, false)
+	line := position.Line
+	if line >= tokenFile.LineCount() {
+		return nil
+	}
+
+	rest := strings.TrimSpace(lines[line-1][position.Column:])
+	if rest != "" && !strings.HasPrefix(rest, "//") {
+		return nil
+	}
+
+	nextLineStart := tokenFile.LineStart(line + 1)
+	return []analysis.SuggestedFix{{
+		Message: message,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     nextLineStart,
+			End:     nextLineStart,
+			NewText: []byte("\n"),
+		}},
+	}}
+}
+
+// insertLineBreakBeforeRparenFix moves ) of the call to the next line with
+// the indentation of the line of (, the trailing comma is added if needed.
+// Comments between the last argument and ) are not moved.
+func insertLineBreakBeforeRparenFix(
+	pass *analysis.Pass,
+	callExpression *ast.CallExpr,
+	argumentsEnd token.Pos,
+	lines []string,
+) []analysis.SuggestedFix {
+
+	tokenFile := pass.Fset.File(callExpression.Rparen)
+	// Both positions are on the same line, it is checked by the caller.
+	argumentsEndPosition := tokenFile.PositionFor(argumentsEnd, false)
+	rparenPosition := tokenFile.PositionFor(callExpression.Rparen, false)
+	line := lines[rparenPosition.Line-1]
+	between := line[argumentsEndPosition.Column-1 : rparenPosition.Column-1]
+	between = strings.TrimSpace(between)
+	if between != "" && between != "," {
+		return nil
+	}
+
+	lparenLine := lines[tokenFile.PositionFor(callExpression.Lparen, false).Line-1]
+	indentation := lparenLine[:len(lparenLine)-
+		len(strings.TrimLeft(lparenLine, " \t"))]
+	return []analysis.SuggestedFix{{
+		Message: "Move ) to a new line",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     argumentsEnd,
+			End:     callExpression.Rparen,
+			NewText: []byte(",\n" + indentation),
+		}},
+	}}
+}
@@ -10,10 +10,19 @@ import (
 
 func TestLineBreaksAnalyzer(t *testing.T) {
 	analysistest.TestData()
-	analysistest.Run(
+	analysistest.RunWithSuggestedFixes(
 		t,
 		testcommon.TestdataDir(t),
 		LineBreakAfterRbracket(),
 		"example/",
 	)
 }
+
+func TestLineBreaksAnalyzerExamples(t *testing.T) {
+	testcommon.RunExamples(
+		t,
+		testcommon.TestdataDir(t),
+		LineBreakAfterRbracket(),
+		Rules(),
+	)
+}
@@ -0,0 +1,16 @@
+package example
+
+import "fmt"
+
+// Fixes should not be confused by //line directives
+// and should keep comments intact.
+
+//line generated.tmpl:100
+func lineDirectiveExample() {
+	if true {
+		fmt.Println("Something")
+		// Comment before the empty line
+
+	} // want "Line break before closing } is not allowed." "Line break after closing } is required."
+	fmt.Println("Nothing")
+}
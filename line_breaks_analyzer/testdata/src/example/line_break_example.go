@@ -53,7 +53,7 @@ func example() {
 
 	fmt.Println(Example{a: 24})
 	fmt.Println(
-		Example{a: 24})
+		Example{a: 24}) // want `Line break before \) in multiline call is required.`
 	fmt.Println(
 		Example{
 			a: 24,
@@ -148,3 +148,28 @@ func doBracketedStuff() {
 	})
 	fmt.Println(c * d)
 }
+
+func elseBranch(a int) {
+	if a > 0 {
+		fmt.Println(a)
+	} else { // want "Line break after closing } is required."
+		fmt.Println(-a)
+	}
+
+	fmt.Println("done")
+}
+
+func multilineCalls(values []any) {
+	fmt.Println("a",
+		"b") // want `Line break before \) in multiline call is required.`
+	fmt.Println(
+		values...) // want `Line break before \) in multiline call is required.`
+	fmt.Println(
+		"a" /* comment */) // want `Line break before \) in multiline call is required.`
+	fmt.Println("a", func() int {
+		return 1
+	}())
+	fmt.Println(
+		"a",
+	)
+}
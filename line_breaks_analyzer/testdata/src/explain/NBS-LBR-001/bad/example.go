@@ -0,0 +1,16 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func printPositive(values []int) {
+	for _, value := range values {
+		if value > 0 {
+			fmt.Println(value)
+		}
+
+	} // want "Line break before closing } is not allowed."
+}
@@ -0,0 +1,15 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func printPositive(values []int) {
+	for _, value := range values {
+		if value > 0 {
+			fmt.Println(value)
+		}
+	}
+}
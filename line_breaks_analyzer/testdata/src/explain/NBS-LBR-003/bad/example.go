@@ -0,0 +1,20 @@
+package example
+
+import (
+	"os"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func readConfig(name string) ([]byte, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close() // want "Line break before 'defer' statement is not allowed."
+
+	data := make([]byte, 1024)
+	n, err := file.Read(data)
+	return data[:n], err
+}
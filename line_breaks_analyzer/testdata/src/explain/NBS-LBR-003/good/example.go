@@ -0,0 +1,19 @@
+package example
+
+import (
+	"os"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func readConfig(name string) ([]byte, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data := make([]byte, 1024)
+	n, err := file.Read(data)
+	return data[:n], err
+}
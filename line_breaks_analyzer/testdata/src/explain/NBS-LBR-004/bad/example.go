@@ -0,0 +1,15 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func notify(customer string, order int, address string) {
+	fmt.Printf(
+		"Dear %s, your order %d has been shipped to %s and arrives tomorrow.\n",
+		customer,
+		order,
+		address) // want `Line break before \) in multiline call is required.`
+}
@@ -0,0 +1,16 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func notify(customer string, order int, address string) {
+	fmt.Printf(
+		"Dear %s, your order %d has been shipped to %s and arrives tomorrow.\n",
+		customer,
+		order,
+		address,
+	)
+}
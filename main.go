@@ -4,23 +4,33 @@ import (
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/golangci/plugin-module-register/register"
-
-	"github.com/jkuradobery/nbs-go-lint/line_breaks_analyzer"
-	signature "github.com/jkuradobery/nbs-go-lint/multiline_signature_analyzer"
-	"github.com/jkuradobery/nbs-go-lint/separator_analyzer"
 )
 
-type NbsAnalyzerPlugin struct{}
+////////////////////////////////////////////////////////////////////////////////
+
+type NbsAnalyzerPlugin struct {
+	settings Settings
+}
+
+func NewNbsAnalyzerPlugin(conf any) (*NbsAnalyzerPlugin, error) {
+	settings, err := DecodeSettings(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NbsAnalyzerPlugin{settings: settings}, nil
+}
 
 func (n NbsAnalyzerPlugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
-	return []*analysis.Analyzer{
-		line_breaks_analyzer.LineBreakAfterRbracket(),
-		separator_analyzer.SeparatorAnalyzer(),
-		signature.LineBreakAfterMultilineFunctionSignatureAnalyzer(),
-	}, nil
+	return Analyzers(n.settings), nil
 }
 
 func (n NbsAnalyzerPlugin) GetLoadMode() string {
+	if n.settings.Separator.IsEnabled() &&
+		n.settings.Separator.TypeCheckedConstructors {
+		return register.LoadModeTypesInfo
+	}
+
 	return register.LoadModeSyntax
 }
 
@@ -28,7 +38,7 @@ func init() {
 	register.Plugin(
 		"nbs-go-lint",
 		func(conf any) (register.LinterPlugin, error) {
-			return &NbsAnalyzerPlugin{}, nil
+			return NewNbsAnalyzerPlugin(conf)
 		},
 	)
 }
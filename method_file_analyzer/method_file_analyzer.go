@@ -0,0 +1,194 @@
+package method_file_analyzer
+
+import (
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/separator_analyzer"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const RuleMethodInTypeFile = "method-in-type-file"
+
+const CodeMethodInTypeFile = "NBS-MTH-001"
+
+const MethodInAnotherFileFormat = "Method '%s' should be in the file %s with its receiver type '%s'"
+
+const Category = "methods"
+
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed testdata/src/explain
+var examples embed.FS
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Rules() []rules.Rule {
+	return rules.WithExamples(examples, []rules.Rule{{
+		ID:   RuleMethodInTypeFile,
+		Code: CodeMethodInTypeFile,
+		Doc:  "Methods are declared in the same file as their receiver type.",
+		Rationale: "The file of a type is the place where readers look for its " +
+			"behaviour, methods scattered over the package are easy to miss.",
+	}})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// DefaultAllowedFiles are the patterns of files which may declare methods of
+// types from other files.
+var DefaultAllowedFiles = []string{"*_test.go", "*_mock.go"}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Settings configure MethodFileAnalyzer, they are decoded from the
+// golangci-lint plugin configuration.
+type Settings struct {
+	rules.Toggle
+
+	// AllowedFiles are filepath.Match patterns of file names which may
+	// declare methods of types from other files. DefaultAllowedFiles are used
+	// if it is nil.
+	AllowedFiles []string `json:"allowed-files,omitempty"`
+}
+
+func (s Settings) Validate() error {
+	for _, pattern := range s.AllowedFiles {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid allowed-files pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func MethodFileAnalyzer(
+	options ...rules.Option[Settings],
+) *analysis.Analyzer {
+
+	settings := rules.ApplyOptions(options...)
+
+	if settings.AllowedFiles == nil {
+		settings.AllowedFiles = DefaultAllowedFiles
+	}
+
+	analyzer := &analysis.Analyzer{
+		Name: "MethodFileAnalyzer",
+		Doc:  "Checks that methods are declared in the same file as their receiver type.",
+		URL:  rules.DocumentationURL,
+		Run: func(pass *analysis.Pass) (any, error) {
+			if err := settings.Validate(); err != nil {
+				return nil, err
+			}
+
+			checkMethodFiles(pass, settings.AllowedFiles)
+			return nil, nil
+		},
+	}
+	analyzer.Flags.Func(
+		"allowed-files",
+		fmt.Sprintf(
+			"comma separated patterns of files which may declare methods of types from other files (default %q)",
+			strings.Join(DefaultAllowedFiles, ","),
+		),
+		func(value string) error {
+			settings.AllowedFiles = splitPatterns(value)
+			return settings.Validate()
+		},
+	)
+
+	return analyzer
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func splitPatterns(value string) []string {
+	patterns := make([]string, 0)
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
+func isAllowedFile(filename string, allowedFiles []string) bool {
+	for _, pattern := range allowedFiles {
+		if matched, _ := filepath.Match(pattern, filepath.Base(filename)); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// checkMethodFiles looks at all files of the package together, as the type
+// and its methods may be declared in any of them. Types from generated files
+// are skipped, their methods can not be moved there.
+func checkMethodFiles(pass *analysis.Pass, allowedFiles []string) {
+	typeFiles := make(map[string]string)
+	for _, file := range pass.Files {
+		if ast.IsGenerated(file) {
+			continue
+		}
+
+		filename := pass.Fset.PositionFor(file.Pos(), false).Filename
+		for _, declaration := range file.Decls {
+			genDecl, ok := declaration.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeFiles[spec.(*ast.TypeSpec).Name.Name] = filename
+			}
+		}
+	}
+
+	for _, file := range pass.Files {
+		filename := pass.Fset.PositionFor(file.Pos(), false).Filename
+		if ast.IsGenerated(file) || isAllowedFile(filename, allowedFiles) {
+			continue
+		}
+
+		for _, declaration := range file.Decls {
+			function, ok := declaration.(*ast.FuncDecl)
+			if !ok || function.Recv == nil || len(function.Recv.List) == 0 {
+				continue
+			}
+
+			typeName := separator_analyzer.BaseTypeName(function.Recv.List[0].Type)
+			typeFile, ok := typeFiles[typeName]
+			if !ok || typeFile == filename {
+				continue
+			}
+
+			pass.Report(analysis.Diagnostic{
+				Pos:      function.Pos(),
+				End:      function.Type.End(),
+				Category: Category,
+				URL:      rules.URL(CodeMethodInTypeFile),
+				Message: fmt.Sprintf(
+					MethodInAnotherFileFormat,
+					function.Name.Name,
+					filepath.Base(typeFile),
+					typeName,
+				),
+			})
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package method_file_analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jkuradobery/nbs-go-lint/testcommon"
+)
+
+func TestMethodFileAnalyzer(t *testing.T) {
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		MethodFileAnalyzer(),
+		"example/",
+	)
+}
+
+func TestMethodFileAnalyzerAllowedFiles(t *testing.T) {
+	analyzer := MethodFileAnalyzer()
+	require.NoError(t, analyzer.Flags.Parse([]string{
+		"-allowed-files=*_methods.go",
+	}))
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		analyzer,
+		"allowed/",
+	)
+
+	require.Error(t, analyzer.Flags.Set("allowed-files", "[a-"))
+}
+
+func TestMethodFileAnalyzerExamples(t *testing.T) {
+	testcommon.RunExamples(
+		t,
+		testcommon.TestdataDir(t),
+		MethodFileAnalyzer(),
+		Rules(),
+	)
+}
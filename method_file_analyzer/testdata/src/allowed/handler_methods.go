@@ -0,0 +1,7 @@
+package allowed
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (h *Handler) Name() string {
+	return h.name
+}
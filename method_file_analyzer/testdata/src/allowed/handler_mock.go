@@ -0,0 +1,7 @@
+package allowed
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (h *Handler) SetName(name string) { // want `Method 'SetName' should be in the file types.go with its receiver type 'Handler'`
+	h.name = name
+}
@@ -0,0 +1,7 @@
+package allowed
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Handler struct {
+	name string
+}
@@ -0,0 +1,7 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (c *Client) MockAddress(address string) {
+	c.address = address
+}
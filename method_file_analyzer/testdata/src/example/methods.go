@@ -0,0 +1,27 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Server struct {
+	clients []*Client
+}
+
+func (s *Server) Clients() []*Client {
+	return s.clients
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (c *Client) Close() error { // want `Method 'Close' should be in the file types.go with its receiver type 'Client'`
+	return nil
+}
+
+func (s Set[T]) Len() int { // want `Method 'Len' should be in the file types.go with its receiver type 'Set'`
+	return len(s.items)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (r *Request) Validate() error {
+	return nil
+}
@@ -0,0 +1,25 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Client struct {
+	address string
+}
+
+func NewClient(address string) *Client {
+	return &Client{address: address}
+}
+
+func (c *Client) Address() string {
+	return c.address
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+func (s *Set[T]) Add(item T) {
+	s.items[item] = struct{}{}
+}
@@ -0,0 +1,10 @@
+package example
+
+import "testing"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (s *Server) addTestClient(t *testing.T) {
+	t.Helper()
+	s.clients = append(s.clients, NewClient("test"))
+}
@@ -0,0 +1,11 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Account struct {
+	balance int
+}
+
+func (a *Account) Deposit(amount int) {
+	a.balance += amount
+}
@@ -0,0 +1,11 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (a *Account) String() string { // want "Method 'String' should be in the file account.go with its receiver type 'Account'"
+	return fmt.Sprintf("balance: %d", a.balance)
+}
@@ -0,0 +1,19 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Account struct {
+	balance int
+}
+
+func (a *Account) Deposit(amount int) {
+	a.balance += amount
+}
+
+func (a *Account) String() string {
+	return fmt.Sprintf("balance: %d", a.balance)
+}
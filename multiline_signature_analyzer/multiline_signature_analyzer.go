@@ -1,24 +1,75 @@
 package multiline_signature_analyzer
 
 import (
+	"embed"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"log"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func LineBreakAfterMultilineFunctionSignatureAnalyzer() *analysis.Analyzer {
+const RuleLineBreakAfterSignature = "line-break-after-multiline-signature"
+
+const CodeLineBreakAfterSignature = "NBS-SIG-001"
+
+const Category = "signature"
+
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed testdata/src/explain
+var examples embed.FS
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Rules() []rules.Rule {
+	return rules.WithExamples(examples, []rules.Rule{{
+		ID:   RuleLineBreakAfterSignature,
+		Code: CodeLineBreakAfterSignature,
+		Doc:  "The body of a function with a multiline signature starts with exactly one empty line.",
+		Rationale: "Without the empty line the last parameter and the first statement " +
+			"are indented equally and the signature blends into the body.",
+	}})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Settings configure LineBreakAfterMultilineFunctionSignatureAnalyzer, they
+// are decoded from the golangci-lint plugin configuration.
+type Settings struct {
+	rules.Toggle
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// LineBreakAfterMultilineFunctionSignatureAnalyzer ignores the options, its
+// settings only switch it on and off.
+func LineBreakAfterMultilineFunctionSignatureAnalyzer(
+	options ...rules.Option[Settings],
+) *analysis.Analyzer {
+
 	return &analysis.Analyzer{
 		Name: "LineBreakAfterMultilineFunctionSignatureAnalyzer",
 		Doc:  "Checks for line breaks after multiline function signatures.",
+		URL:  rules.DocumentationURL,
 		Run: func(pass *analysis.Pass) (any, error) {
 			for _, file := range pass.Files {
+				filename := pass.Fset.PositionFor(file.Pos(), false).Filename
+				data, err := pass.ReadFile(filename)
+				if err != nil {
+					log.Fatalf("Error reading file %v: %v", filename, err)
+				}
+
+				lines := strings.Split(string(data), "\n")
 				ast.Inspect(file, func(node ast.Node) bool {
 					if function, ok := node.(*ast.FuncDecl); ok {
-						processSingleFunction(pass, function, file)
+						processSingleFunction(pass, function, file, lines)
 					}
 
 					return true
@@ -40,7 +91,7 @@ func findCommentBetweenFirstStatementAndLBrace(
 ) (token.Position, bool) {
 
 	for _, comment := range file.Comments {
-		commentPosition := fset.Position(comment.Pos())
+		commentPosition := fset.PositionFor(comment.Pos(), false)
 		// Skip comments that are not between the lbrace and the first statement
 		if commentPosition.Line <= lbracePosition.Line {
 			continue
@@ -60,28 +111,30 @@ func processSingleFunction(
 	pass *analysis.Pass,
 	function *ast.FuncDecl,
 	file *ast.File,
+	lines []string,
 ) {
 	isMultiline := false
 	params := function.Type.Params
 	fset := pass.Fset
-	opening := fset.Position(params.Opening)
-	closing := fset.Position(params.Closing)
+	opening := fset.PositionFor(params.Opening, false)
+	closing := fset.PositionFor(params.Closing, false)
 	if opening.Line < closing.Line {
 		isMultiline = true
 	}
 
 	body := function.Body
-	lbracePosition := fset.Position(body.Lbrace)
-	if !isMultiline {
+	if !isMultiline || body == nil {
 		return
 	}
 
+	lbracePosition := fset.PositionFor(body.Lbrace, false)
+
 	stmt := body.Rbrace
 	if len(body.List) > 0 {
 		stmt = body.List[0].Pos()
 	}
 
-	firstStmtPosition := fset.Position(stmt)
+	firstStmtPosition := fset.PositionFor(stmt, false)
 	commentPosition, ok := findCommentBetweenFirstStatementAndLBrace(
 		fset,
 		firstStmtPosition,
@@ -97,6 +150,12 @@ func processSingleFunction(
 		return
 	}
 
+	// The closing brace of an empty body may follow the opening one, as
+	// LineBreakAfterRbracket forbids empty lines before it.
+	if difference == 1 && len(body.List) == 0 && !ok {
+		return
+	}
+
 	message := "Line break after multiline " +
 		"function signature is required."
 	if difference > 2 {
@@ -110,7 +169,87 @@ func processSingleFunction(
 	pass.Report(analysis.Diagnostic{
 		Pos:      body.Lbrace,
 		End:      stmt,
-		Category: "line_breaks",
+		Category: Category,
+		URL:      rules.URL(CodeLineBreakAfterSignature),
 		Message:  message,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Leave exactly one line break after the signature",
+			TextEdits: lineBreaksAfterSignatureEdits(
+				pass,
+				function,
+				file,
+				lines,
+				difference,
+			),
+		}},
 	})
 }
+
+// lineBreaksAfterSignatureEdits leaves exactly one empty line between
+// the opening brace and the first statement or comment of the body, the
+// closing brace of an empty body is moved to the next line.
+// Statements moved from the line of the brace are indented one level deeper
+// than the function.
+func lineBreaksAfterSignatureEdits(
+	pass *analysis.Pass,
+	function *ast.FuncDecl,
+	file *ast.File,
+	lines []string,
+	difference int,
+) []analysis.TextEdit {
+
+	body := function.Body
+	tokenFile := pass.Fset.File(body.Lbrace)
+	lbraceLine := tokenFile.PositionFor(body.Lbrace, false).Line
+	switch {
+	case difference > 2:
+		return []analysis.TextEdit{{
+			Pos: tokenFile.LineStart(lbraceLine + 2),
+			End: tokenFile.LineStart(lbraceLine + difference),
+		}}
+	case difference == 1:
+		nextLineStart := tokenFile.LineStart(lbraceLine + 1)
+		return []analysis.TextEdit{{
+			Pos:     nextLineStart,
+			End:     nextLineStart,
+			NewText: []byte("\n"),
+		}}
+	}
+
+	// The body starts on the line of the opening brace.
+	functionLine := lines[tokenFile.PositionFor(function.Pos(), false).Line-1]
+	indentation := functionLine[:len(functionLine)-
+		len(strings.TrimLeft(functionLine, " \t"))]
+	if len(body.List) == 0 {
+		return []analysis.TextEdit{{
+			Pos:     body.Lbrace + 1,
+			End:     body.Rbrace,
+			NewText: []byte("\n" + indentation),
+		}}
+	}
+
+	// Comments after the brace stay on its line.
+	start := body.Lbrace + 1
+	for _, group := range file.Comments {
+		if group.Pos() > body.Lbrace && group.End() <= body.List[0].Pos() {
+			start = group.End()
+		}
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     start,
+		End:     body.List[0].Pos(),
+		NewText: []byte("\n\n" + indentation + "\t"),
+	}}
+	lastStmt := body.List[len(body.List)-1]
+	lastStmtLine := tokenFile.PositionFor(lastStmt.End(), false).Line
+	if lastStmtLine == tokenFile.PositionFor(body.Rbrace, false).Line {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     lastStmt.End(),
+			End:     body.Rbrace,
+			NewText: []byte("\n" + indentation),
+		})
+	}
+
+	return edits
+}
@@ -9,10 +9,19 @@ import (
 
 func TestLineBreakAfterMultilineFunctionSignatureAnalyzer(t *testing.T) {
 	analysistest.TestData()
-	analysistest.Run(
+	analysistest.RunWithSuggestedFixes(
 		t,
 		testcommon.TestdataDir(t),
 		LineBreakAfterMultilineFunctionSignatureAnalyzer(),
 		"example/",
 	)
 }
+
+func TestLineBreakAfterMultilineFunctionSignatureAnalyzerExamples(t *testing.T) {
+	testcommon.RunExamples(
+		t,
+		testcommon.TestdataDir(t),
+		LineBreakAfterMultilineFunctionSignatureAnalyzer(),
+		Rules(),
+	)
+}
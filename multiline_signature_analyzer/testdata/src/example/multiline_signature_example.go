@@ -77,6 +77,14 @@ func someFunctionWithEmptyBody(
 
 }
 
+// This is valid
+//goland:noinspection GoUnusedFunction,GoUnusedParameter
+func someFunctionWithEmptyBodyOnTheNextLine(
+	firstUselessArgument []string,
+	secondUselessArgument map[string]string,
+){
+}
+
 // This is invalid
 //goland:noinspection GoUnusedFunction,GoUnusedParameter
 func someFunctionWithEmptyBodyFailsLinter(
@@ -96,3 +104,17 @@ func someFunctionWithEmptyBodyMultipleLineInside(
 
 }
 // @formatter:on
+
+// This is invalid
+//goland:noinspection GoUnusedFunction,GoUnusedParameter
+func someFunctionWithBodyOnTheSameLine(
+	firstUselessArgument []string,
+	secondUselessArgument map[string]string,
+) int { return len(firstUselessArgument) } // want "Line break after multiline function signature is required."
+
+// This is invalid
+//goland:noinspection GoUnusedFunction,GoUnusedParameter
+func someFunctionWithCommentAndBodyOnTheSameLine(
+	firstUselessArgument []string,
+	secondUselessArgument map[string]string,
+) int { /* the comment is kept */ return len(firstUselessArgument) } // want "Line break after multiline function signature is required."
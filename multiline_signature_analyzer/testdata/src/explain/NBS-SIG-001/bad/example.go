@@ -0,0 +1,28 @@
+package example
+
+import (
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Order struct {
+	ID        int
+	Customer  string
+	Address   string
+	CreatedAt time.Time
+}
+
+func NewOrder(
+	id int,
+	customer string,
+	address string,
+	createdAt time.Time,
+) *Order { // want "Line break after multiline function signature is required."
+	return &Order{
+		ID:        id,
+		Customer:  customer,
+		Address:   address,
+		CreatedAt: createdAt,
+	}
+}
@@ -0,0 +1,29 @@
+package example
+
+import (
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Order struct {
+	ID        int
+	Customer  string
+	Address   string
+	CreatedAt time.Time
+}
+
+func NewOrder(
+	id int,
+	customer string,
+	address string,
+	createdAt time.Time,
+) *Order {
+
+	return &Order{
+		ID:        id,
+		Customer:  customer,
+		Address:   address,
+		CreatedAt: createdAt,
+	}
+}
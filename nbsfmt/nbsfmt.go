@@ -0,0 +1,246 @@
+package nbsfmt
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/analysis"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// maxIterations bounds the number of fix rounds for fixes which keep
+// producing new diagnostics.
+const maxIterations = 10
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Source applies the suggested fixes of the analyzers to the file and formats
+// the result with gofmt. Analyzers are run one after another, each on the
+// result of the previous one, in rounds until the file does not change, so
+// the result is stable: formatting it again gives the same source.
+//
+// The file is formatted only at the end of a round, as gofmt turns separators
+// glued to doc comments into "// ////" before they are fixed.
+//
+// The file is only parsed, analyzers which need type information are not
+// supported. Analyzers which require results of other analyzers, such as the
+// check of unused suppressions, are skipped.
+func Source(
+	filename string,
+	src []byte,
+	analyzers []*analysis.Analyzer,
+) ([]byte, error) {
+
+	analyzers = slices.DeleteFunc(
+		slices.Clone(analyzers),
+		func(analyzer *analysis.Analyzer) bool {
+			return len(analyzer.Requires) != 0
+		},
+	)
+
+	module := findModule(filename)
+	for i := 0; i < maxIterations; i++ {
+		fixed := src
+		for _, analyzer := range analyzers {
+			var err error
+			fixed, err = fix(filename, fixed, module, analyzer)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		formatted, err := format.Source(fixed)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+
+		if bytes.Equal(formatted, src) {
+			break
+		}
+
+		src = formatted
+	}
+
+	return src, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// fix applies the fixes of the analyzer which do not conflict with each other.
+func fix(
+	filename string,
+	src []byte,
+	module *analysis.Module,
+	analyzer *analysis.Analyzer,
+) ([]byte, error) {
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostics, err := run(analyzer, fset, file, src, module)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", filename, analyzer.Name, err)
+	}
+
+	var fixes []analysis.SuggestedFix
+	for _, diagnostic := range diagnostics {
+		fixes = append(fixes, diagnostic.SuggestedFixes...)
+	}
+
+	tokenFile := fset.File(file.Pos())
+	return apply(src, selectEdits(tokenFile, fixes)), nil
+}
+
+func run(
+	analyzer *analysis.Analyzer,
+	fset *token.FileSet,
+	file *ast.File,
+	src []byte,
+	module *analysis.Module,
+) ([]analysis.Diagnostic, error) {
+
+	filename := fset.PositionFor(file.Pos(), false).Filename
+	var diagnostics []analysis.Diagnostic
+	pass := &analysis.Pass{
+		Analyzer:  analyzer,
+		Fset:      fset,
+		Files:     []*ast.File{file},
+		Pkg:       types.NewPackage(file.Name.Name, file.Name.Name),
+		TypesInfo: &types.Info{},
+		ResultOf:  map[*analysis.Analyzer]any{},
+		Module:    module,
+		ReadFile: func(name string) ([]byte, error) {
+			if name != filename {
+				return nil, fmt.Errorf("unexpected file %s", name)
+			}
+
+			return src, nil
+		},
+		Report: func(diagnostic analysis.Diagnostic) {
+			diagnostics = append(diagnostics, diagnostic)
+		},
+	}
+
+	_, err := analyzer.Run(pass)
+	return diagnostics, err
+}
+
+// findModule returns the module of the nearest go.mod above the file, so
+// analyzers can tell local imports from third-party ones. It returns nil
+// outside of modules.
+func findModule(filename string) *analysis.Module {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil
+	}
+
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			return &analysis.Module{Path: modfile.ModulePath(data)}
+		}
+
+		if filepath.Dir(dir) == dir {
+			return nil
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// edit is an analysis.TextEdit with offsets instead of positions.
+type edit struct {
+	start   int
+	end     int
+	newText []byte
+}
+
+func (e edit) conflicts(other edit) bool {
+	if e.start == other.start && e.end == other.end {
+		return !bytes.Equal(e.newText, other.newText)
+	}
+
+	// Two insertions at the same offset conflict, as their order is unknown.
+	return e.start < other.end && other.start < e.end ||
+		e.start == other.start
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// selectEdits takes the edits of all fixes which do not conflict with the
+// fixes taken before. Skipped fixes are applied in the next rounds if they
+// are still suggested.
+func selectEdits(
+	tokenFile *token.File,
+	fixes []analysis.SuggestedFix,
+) []edit {
+
+	var selected []edit
+	for _, fix := range fixes {
+		edits := make([]edit, 0, len(fix.TextEdits))
+		for _, textEdit := range fix.TextEdits {
+			end := textEdit.End
+			if !end.IsValid() {
+				end = textEdit.Pos
+			}
+
+			edits = append(edits, edit{
+				start:   tokenFile.Offset(textEdit.Pos),
+				end:     tokenFile.Offset(end),
+				newText: textEdit.NewText,
+			})
+		}
+
+		if !hasConflicts(selected, edits) {
+			selected = append(selected, edits...)
+		}
+	}
+
+	slices.SortFunc(selected, func(a, b edit) int {
+		return a.start - b.start
+	})
+
+	return slices.CompactFunc(selected, func(a, b edit) bool {
+		return a.start == b.start &&
+			a.end == b.end &&
+			bytes.Equal(a.newText, b.newText)
+	})
+}
+
+func hasConflicts(selected []edit, edits []edit) bool {
+	for _, edit := range edits {
+		for _, other := range selected {
+			if edit.conflicts(other) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// apply expects edits sorted by offset which do not overlap.
+func apply(src []byte, edits []edit) []byte {
+	result := make([]byte, 0, len(src))
+	last := 0
+	for _, edit := range edits {
+		result = append(result, src[last:edit.start]...)
+		result = append(result, edit.newText...)
+		last = edit.end
+	}
+
+	return append(result, src[last:]...)
+}
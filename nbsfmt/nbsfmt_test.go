@@ -0,0 +1,75 @@
+package nbsfmt
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+	"github.com/jkuradobery/nbs-go-lint/line_breaks_analyzer"
+	signature "github.com/jkuradobery/nbs-go-lint/multiline_signature_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/testcommon"
+)
+
+func TestSource(t *testing.T) {
+	filename := filepath.Join(testcommon.TestdataDir(t), "example.go")
+	src, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	golden, err := os.ReadFile(filename + ".golden")
+	require.NoError(t, err)
+
+	analyzers := nbs.Analyzers(nbs.Settings{}.WithOptInAnalyzers())
+	formatted, err := Source(filename, src, analyzers)
+	require.NoError(t, err)
+	require.Equal(t, string(golden), string(formatted))
+
+	gofmted, err := format.Source(formatted)
+	require.NoError(t, err)
+	require.Equal(t, string(formatted), string(gofmted))
+
+	again, err := Source(filename, formatted, analyzers)
+	require.NoError(t, err)
+	require.Equal(t, string(formatted), string(again))
+}
+
+// TestSourceEmptyBody checks that the fixes of the signature and the line
+// breaks analyzers agree on empty bodies, so neither reports the result.
+func TestSourceEmptyBody(t *testing.T) {
+	filename := filepath.Join(testcommon.TestdataDir(t), "empty_body.go")
+	src, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	golden, err := os.ReadFile(filename + ".golden")
+	require.NoError(t, err)
+
+	analyzers := []*analysis.Analyzer{
+		signature.LineBreakAfterMultilineFunctionSignatureAnalyzer(),
+		line_breaks_analyzer.LineBreakAfterRbracket(),
+	}
+	formatted, err := Source(filename, src, analyzers)
+	require.NoError(t, err)
+	require.Equal(t, string(golden), string(formatted))
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, formatted, parser.ParseComments)
+	require.NoError(t, err)
+	for _, analyzer := range analyzers {
+		diagnostics, err := run(analyzer, fset, file, formatted, nil)
+		require.NoError(t, err)
+		require.Empty(t, diagnostics, analyzer.Name)
+	}
+}
+
+func TestSourceSyntaxError(t *testing.T) {
+	_, err := Source(
+		"broken.go",
+		[]byte("package broken\n\nfunc {"),
+		nbs.Analyzers(nbs.Settings{}),
+	)
+	require.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package example
+
+func emptyBody(
+	a int,
+) {}
+
+func emptyLine(
+	a int,
+) {
+
+}
+
+func emptyLines(
+	a int,
+) {
+
+
+
+}
+
+func nextLine(
+	a int,
+) {
+}
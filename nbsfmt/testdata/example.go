@@ -0,0 +1,57 @@
+package example
+
+import (
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"fmt"
+)
+////////////////////////////////////////////////////////////////////////////////
+type Example struct {
+	a int
+}
+
+func NewExample(
+	a int,
+) *Example {
+	return &Example{a: a}
+}
+
+func (e *Example) Print() {
+	if e.a > 0 {
+		fmt.Println(e.a)
+
+	}
+	fmt.Println("done")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func runExampleWithAVeryLongName(
+	example *Example,
+	description string,
+	details map[string]string,
+) {
+
+
+
+	defer fmt.Println("deferred")
+	example.Print()
+	fmt.Println(
+		description,
+		details,
+		rules.All,
+	)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+//nbs:ignore line_breaks,signature,single_line the example keeps its formatting
+func suppressed(
+	example *Example,
+) {
+	if example.a > 0 {
+		fmt.Println(example.a)
+	}
+	fmt.Println("done")
+}
+
+////////////////////////////////////////////////////////////////////////////////
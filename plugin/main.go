@@ -0,0 +1,33 @@
+// Package main is the Go plugin entry point for golangci-lint versions that
+// load linters from .so files:
+//
+//	CGO_ENABLED=1 go build -buildmode=plugin plugin/main.go
+//
+// Newer golangci-lint versions should use the module plugin registered by
+// the root package instead.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// New is looked up by golangci-lint, conf is the "settings" section of the
+// custom linter configuration.
+func New(conf any) ([]*analysis.Analyzer, error) {
+	plugin, err := nbs.NewNbsAnalyzerPlugin(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return plugin.BuildAnalyzers()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// main is never called, it only keeps "go build ./..." working for the
+// plugin package.
+func main() {}
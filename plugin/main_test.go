@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	analyzers, err := New(nil)
+	require.NoError(t, err)
+	require.Len(t, analyzers, 6)
+
+	analyzers, err = New(map[string]any{
+		"line-breaks": map[string]any{
+			"enabled": false,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, analyzers, 5)
+
+	_, err = New(map[string]any{
+		"linebreaks": map[string]any{},
+	})
+	require.ErrorContains(t, err, `unknown field "linebreaks"`)
+}
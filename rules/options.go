@@ -0,0 +1,46 @@
+package rules
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Toggle switches an analyzer on and off in the plugin settings, it is
+// embedded in the settings of analyzers enabled by default.
+type Toggle struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+func (t Toggle) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// OptIn is the Toggle of analyzers disabled unless Enabled is set.
+type OptIn struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+func (t OptIn) IsEnabled() bool {
+	return t.Enabled != nil && *t.Enabled
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Option configures the settings S of an analyzer constructor.
+type Option[S any] func(settings *S)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func WithSettings[S any](settings S) Option[S] {
+	return func(s *S) {
+		*s = settings
+	}
+}
+
+// ApplyOptions returns the settings configured by the options, zero values
+// mean defaults.
+func ApplyOptions[S any](options ...Option[S]) S {
+	var settings S
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return settings
+}
@@ -0,0 +1,302 @@
+package rules
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Severity string
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityOff     Severity = "off"
+)
+
+// All can be used instead of a rule ID to configure every rule of a registry.
+const All = "all"
+
+// DocumentationURL is the page describing every rule, rules are anchored by
+// their lowercase codes.
+const DocumentationURL = "https://github.com/jkuradobery/nbs-go-lint/blob/master/docs/rules.md"
+
+// ExamplesDir is the directory of rule examples in the analyzer packages.
+const ExamplesDir = "testdata/src/explain"
+
+// WarningPrefix is prepended to messages of diagnostics downgraded to
+// warnings, so they can be matched by golangci-lint severity rules.
+const WarningPrefix = "warning: "
+
+////////////////////////////////////////////////////////////////////////////////
+
+func ParseSeverity(value string) (Severity, error) {
+	switch severity := Severity(value); severity {
+	case SeverityError, SeverityWarning, SeverityOff:
+		return severity, nil
+	default:
+		return "", fmt.Errorf(
+			"unknown severity %q, expected one of %q, %q, %q",
+			value,
+			SeverityError,
+			SeverityWarning,
+			SeverityOff,
+		)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Rule struct {
+	// ID is a stable identifier used in configuration and flags.
+	ID string
+	// Code is a stable identifier shown in reports and documentation,
+	// e.g. NBS-SEP-004.
+	Code string
+	Doc  string
+	// Rationale explains why the rule exists.
+	Rationale string
+	// Examples contain the bad package violating the rule and the good
+	// package following it.
+	Examples fs.FS
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// URL is the URL of the documentation of the rule with the code, it is set
+// to diagnostics of the rule.
+func URL(code string) string {
+	return DocumentationURL + "#" + strings.ToLower(code)
+}
+
+// CodeFromURL returns the code of the rule documented at the URL, or an empty
+// string for other URLs.
+func CodeFromURL(url string) string {
+	anchor, ok := strings.CutPrefix(url, DocumentationURL+"#")
+	if !ok {
+		return ""
+	}
+
+	return strings.ToUpper(anchor)
+}
+
+// WithExamples sets the examples of the rules from the explain directory of
+// the analyzer testdata, which has a directory with the bad and the good
+// packages per rule code:
+//
+//	//go:embed testdata/src/explain
+//	var examples embed.FS
+func WithExamples(examples fs.FS, rules []Rule) []Rule {
+	for i := range rules {
+		sub, err := fs.Sub(examples, path.Join(ExamplesDir, rules[i].Code))
+		if err == nil {
+			rules[i].Examples = sub
+		}
+	}
+
+	return rules
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Registry keeps the severity of every rule of an analyzer.
+// All rules are reported as errors unless configured otherwise.
+type Registry struct {
+	rules      []Rule
+	severities map[string]Severity
+}
+
+func NewRegistry(rules ...Rule) *Registry {
+	severities := make(map[string]Severity, len(rules))
+	for _, rule := range rules {
+		severities[rule.ID] = SeverityError
+	}
+
+	return &Registry{
+		rules:      rules,
+		severities: severities,
+	}
+}
+
+func (r *Registry) Rules() []Rule {
+	return slices.Clone(r.rules)
+}
+
+// Configure applies severities by rule ID, the "all" key is applied
+// before the others regardless of the map order.
+func (r *Registry) Configure(severities map[string]Severity) error {
+	if severity, ok := severities[All]; ok {
+		if err := r.SetSeverity(All, severity); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]string, 0, len(severities))
+	for id := range severities {
+		if id != All {
+			ids = append(ids, id)
+		}
+	}
+
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		if err := r.SetSeverity(id, severities[id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) SetSeverity(id string, severity Severity) error {
+	severity, err := ParseSeverity(string(severity))
+	if err != nil {
+		return fmt.Errorf("rule %q: %w", id, err)
+	}
+
+	if id == All {
+		for _, rule := range r.rules {
+			r.severities[rule.ID] = severity
+		}
+
+		return nil
+	}
+
+	rule, ok := r.find(id)
+	if !ok {
+		return fmt.Errorf("unknown rule %q", id)
+	}
+
+	r.severities[rule.ID] = severity
+	return nil
+}
+
+// find looks the rule up by the ID or, case-insensitively, by the code.
+func (r *Registry) find(id string) (Rule, bool) {
+	for _, rule := range r.rules {
+		if rule.ID == id || rule.Code != "" && strings.EqualFold(rule.Code, id) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// Severity returns the severity of the rule with the ID or the code.
+func (r *Registry) Severity(id string) Severity {
+	rule, ok := r.find(id)
+	if !ok {
+		return SeverityOff
+	}
+
+	return r.severities[rule.ID]
+}
+
+func (r *Registry) IsEnabled(id string) bool {
+	return r.Severity(id) != SeverityOff
+}
+
+// Report reports the diagnostic according to the severity of the rule.
+func (r *Registry) Report(
+	pass *analysis.Pass,
+	id string,
+	diagnostic analysis.Diagnostic,
+) {
+
+	switch r.Severity(id) {
+	case SeverityOff:
+		return
+	case SeverityWarning:
+		diagnostic.Message = WarningPrefix + diagnostic.Message
+	}
+
+	if diagnostic.URL == "" {
+		if rule, ok := r.find(id); ok && rule.Code != "" {
+			diagnostic.URL = URL(rule.Code)
+		}
+	}
+
+	pass.Report(diagnostic)
+}
+
+// RegisterFlags adds -enable, -disable and -warn flags to the analyzer flags.
+// Every flag accepts a comma separated list of rule IDs or "all",
+// flags are applied in the command line order.
+func (r *Registry) RegisterFlags(flags *flag.FlagSet) {
+	ids := make([]string, 0, len(r.rules))
+	for _, rule := range r.rules {
+		ids = append(ids, rule.ID)
+	}
+
+	known := strings.Join(ids, ", ")
+
+	flags.Var(
+		&severityFlag{registry: r, severity: SeverityError},
+		"enable",
+		"comma separated list of rules to report as errors: "+known,
+	)
+	flags.Var(
+		&severityFlag{registry: r, severity: SeverityOff},
+		"disable",
+		"comma separated list of rules to disable: "+known,
+	)
+	flags.Var(
+		&severityFlag{registry: r, severity: SeverityWarning},
+		"warn",
+		"comma separated list of rules to report as warnings: "+known,
+	)
+}
+
+// FlagsRegistry returns the registry which registered its flags in the flag
+// set, or nil, so drivers can look up the severity of a reported rule.
+func FlagsRegistry(flags *flag.FlagSet) *Registry {
+	f := flags.Lookup("warn")
+	if f == nil {
+		return nil
+	}
+
+	value, ok := f.Value.(*severityFlag)
+	if !ok {
+		return nil
+	}
+
+	return value.registry
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type severityFlag struct {
+	registry *Registry
+	severity Severity
+	value    string
+}
+
+func (f *severityFlag) String() string {
+	return f.value
+}
+
+func (f *severityFlag) Set(value string) error {
+	for _, id := range strings.Split(value, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+
+		if err := f.registry.SetSeverity(id, f.severity); err != nil {
+			return err
+		}
+	}
+
+	f.value = value
+	return nil
+}
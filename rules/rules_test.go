@@ -0,0 +1,284 @@
+package rules
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newTestRegistry() *Registry {
+	return NewRegistry(
+		Rule{ID: "first", Code: "NBS-TST-001"},
+		Rule{ID: "second", Code: "NBS-TST-002"},
+		Rule{ID: "third"},
+	)
+}
+
+func severities(registry *Registry) map[string]Severity {
+	result := make(map[string]Severity)
+	for _, rule := range registry.Rules() {
+		result[rule.ID] = registry.Severity(rule.ID)
+	}
+
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func TestParseSeverity(t *testing.T) {
+	for _, test := range []struct {
+		value    string
+		severity Severity
+		err      string
+	}{
+		{value: "error", severity: SeverityError},
+		{value: "warning", severity: SeverityWarning},
+		{value: "off", severity: SeverityOff},
+		{value: "", err: `unknown severity ""`},
+		{value: "Error", err: `unknown severity "Error"`},
+		{value: "info", err: `unknown severity "info"`},
+	} {
+		t.Run(test.value, func(t *testing.T) {
+			severity, err := ParseSeverity(test.value)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.severity, severity)
+		})
+	}
+}
+
+func TestRegistryFlags(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		args       []string
+		severities map[string]Severity
+		err        string
+	}{
+		{
+			name: "default",
+			severities: map[string]Severity{
+				"first":  SeverityError,
+				"second": SeverityError,
+				"third":  SeverityError,
+			},
+		},
+		{
+			name: "lists",
+			args: []string{"-disable=first, third", "-warn=second"},
+			severities: map[string]Severity{
+				"first":  SeverityOff,
+				"second": SeverityWarning,
+				"third":  SeverityOff,
+			},
+		},
+		{
+			name: "all first",
+			args: []string{"-disable=all", "-enable=second"},
+			severities: map[string]Severity{
+				"first":  SeverityOff,
+				"second": SeverityError,
+				"third":  SeverityOff,
+			},
+		},
+		{
+			name: "all last",
+			args: []string{"-enable=second", "-warn=all"},
+			severities: map[string]Severity{
+				"first":  SeverityWarning,
+				"second": SeverityWarning,
+				"third":  SeverityWarning,
+			},
+		},
+		{
+			name: "command line order",
+			args: []string{"-warn=first", "-disable=first", "-enable=first"},
+			severities: map[string]Severity{
+				"first":  SeverityError,
+				"second": SeverityError,
+				"third":  SeverityError,
+			},
+		},
+		{
+			name: "code",
+			args: []string{"-warn=nbs-tst-002"},
+			severities: map[string]Severity{
+				"first":  SeverityError,
+				"second": SeverityWarning,
+				"third":  SeverityError,
+			},
+		},
+		{
+			name: "unknown rule",
+			args: []string{"-disable=first,fourth"},
+			err:  `unknown rule "fourth"`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			registry := newTestRegistry()
+			flags := flag.NewFlagSet(test.name, flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			registry.RegisterFlags(flags)
+
+			err := flags.Parse(test.args)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.severities, severities(registry))
+			require.Same(t, registry, FlagsRegistry(flags))
+		})
+	}
+}
+
+func TestFlagsRegistry(t *testing.T) {
+	require.Nil(t, FlagsRegistry(flag.NewFlagSet("empty", flag.ContinueOnError)))
+
+	flags := flag.NewFlagSet("other", flag.ContinueOnError)
+	flags.String("warn", "", "not a rules flag")
+	require.Nil(t, FlagsRegistry(flags))
+}
+
+func TestRegistryConfigure(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		config     map[string]Severity
+		severities map[string]Severity
+		err        string
+	}{
+		{
+			name: "all before rules",
+			config: map[string]Severity{
+				"second": SeverityWarning,
+				All:      SeverityOff,
+			},
+			severities: map[string]Severity{
+				"first":  SeverityOff,
+				"second": SeverityWarning,
+				"third":  SeverityOff,
+			},
+		},
+		{
+			name:   "unknown severity",
+			config: map[string]Severity{"first": "info"},
+			err:    `rule "first": unknown severity "info"`,
+		},
+		{
+			name:   "unknown rule",
+			config: map[string]Severity{"fourth": SeverityOff},
+			err:    `unknown rule "fourth"`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			registry := newTestRegistry()
+			err := registry.Configure(test.config)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.severities, severities(registry))
+		})
+	}
+}
+
+func TestRegistryReport(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		id          string
+		severity    Severity
+		url         string
+		diagnostics []analysis.Diagnostic
+	}{
+		{
+			name:     "error",
+			id:       "first",
+			severity: SeverityError,
+			diagnostics: []analysis.Diagnostic{{
+				Message: "message",
+				URL:     URL("NBS-TST-001"),
+			}},
+		},
+		{
+			name:     "warning",
+			id:       "first",
+			severity: SeverityWarning,
+			diagnostics: []analysis.Diagnostic{{
+				Message: WarningPrefix + "message",
+				URL:     URL("NBS-TST-001"),
+			}},
+		},
+		{
+			name:     "off",
+			id:       "first",
+			severity: SeverityOff,
+		},
+		{
+			name:     "custom url",
+			id:       "second",
+			severity: SeverityError,
+			url:      "https://example.com",
+			diagnostics: []analysis.Diagnostic{{
+				Message: "message",
+				URL:     "https://example.com",
+			}},
+		},
+		{
+			name:     "without code",
+			id:       "third",
+			severity: SeverityWarning,
+			diagnostics: []analysis.Diagnostic{{
+				Message: WarningPrefix + "message",
+			}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			registry := newTestRegistry()
+			require.NoError(t, registry.SetSeverity(test.id, test.severity))
+			require.Equal(t, test.severity != SeverityOff, registry.IsEnabled(test.id))
+
+			var diagnostics []analysis.Diagnostic
+			pass := &analysis.Pass{
+				Report: func(diagnostic analysis.Diagnostic) {
+					diagnostics = append(diagnostics, diagnostic)
+				},
+			}
+
+			registry.Report(pass, test.id, analysis.Diagnostic{
+				Message: "message",
+				URL:     test.url,
+			})
+			require.Equal(t, test.diagnostics, diagnostics)
+		})
+	}
+}
+
+func TestCodeFromURL(t *testing.T) {
+	for _, test := range []struct {
+		url  string
+		code string
+	}{
+		{url: URL("NBS-SEP-004"), code: "NBS-SEP-004"},
+		{url: DocumentationURL + "#nbs-sln-001", code: "NBS-SLN-001"},
+		{url: DocumentationURL},
+		{url: "https://example.com/rules.md#nbs-sep-004"},
+		{url: ""},
+	} {
+		t.Run(test.url, func(t *testing.T) {
+			require.Equal(t, test.code, CodeFromURL(test.url))
+		})
+	}
+
+	require.Equal(t, DocumentationURL+"#nbs-sep-004", URL("NBS-SEP-004"))
+}
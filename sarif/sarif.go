@@ -0,0 +1,434 @@
+// Package sarif converts issues of the NBS analyzers to a SARIF 2.1.0 log,
+// the format consumed by code scanning dashboards.
+//
+// Every rule of every analyzer becomes a SARIF rule identified by the rule
+// code and named by the rule ID. Results keep the analyzer name and the
+// diagnostic category in their properties, so the issues can be restored
+// from the log.
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+
+	nbs "github.com/jkuradobery/nbs-go-lint"
+	"github.com/jkuradobery/nbs-go-lint/driver"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+const (
+	toolName           = "nbs-go-lint"
+	toolInformationURI = "https://github.com/jkuradobery/nbs-go-lint"
+)
+
+// SrcRoot is the base ID of artifact URIs relative to the analyzed root.
+const SrcRoot = "%SRCROOT%"
+
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+func (l *Log) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(l)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Run struct {
+	Tool               Tool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]ArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	ColumnKind         string                      `json:"columnKind"`
+	Results            []Result                    `json:"results"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Tool struct {
+	Driver ToolComponent `json:"driver"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ToolComponent struct {
+	Name           string                `json:"name"`
+	InformationURI string                `json:"informationUri,omitempty"`
+	Rules          []ReportingDescriptor `json:"rules"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ReportingDescriptor struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name,omitempty"`
+	ShortDescription *Message          `json:"shortDescription,omitempty"`
+	FullDescription  *Message          `json:"fullDescription,omitempty"`
+	Help             *Message          `json:"help,omitempty"`
+	HelpURI          string            `json:"helpUri,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Result struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    Message           `json:"message"`
+	Locations  []Location        `json:"locations"`
+	Fixes      []Fix             `json:"fixes,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           Region           `json:"region"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Region has both line and column and byte coordinates, columns are counted
+// in Unicode code points.
+type Region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+	ByteOffset  int `json:"byteOffset"`
+	ByteLength  int `json:"byteLength"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Fix struct {
+	Description     *Message         `json:"description,omitempty"`
+	ArtifactChanges []ArtifactChange `json:"artifactChanges"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ArtifactChange struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Replacements     []Replacement    `json:"replacements"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Replacement struct {
+	DeletedRegion   Region           `json:"deletedRegion"`
+	InsertedContent *ArtifactContent `json:"insertedContent,omitempty"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ArtifactContent struct {
+	Text string `json:"text"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// New describes the rules of the analyzers and converts the issues, paths
+// inside the root directory are made relative to it.
+func New(
+	root string,
+	analyzers []*analysis.Analyzer,
+	issues []driver.Issue,
+) (*Log, error) {
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := &converter{
+		root:    absRoot,
+		indexes: make(map[string]int),
+		sources: make(map[string][]byte),
+	}
+
+	for _, analyzer := range analyzers {
+		for _, rule := range nbs.Rules(analyzer) {
+			converter.addRule(analyzer.Name, rule)
+		}
+	}
+
+	results := make([]Result, 0, len(issues))
+	for _, issue := range issues {
+		result, err := converter.result(issue)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return &Log{
+		Schema:  Schema,
+		Version: Version,
+		Runs: []Run{{
+			Tool: Tool{Driver: ToolComponent{
+				Name:           toolName,
+				InformationURI: toolInformationURI,
+				Rules:          converter.rules,
+			}},
+			OriginalURIBaseIDs: map[string]ArtifactLocation{
+				SrcRoot: {URI: directoryURI(absRoot)},
+			},
+			ColumnKind: "unicodeCodePoints",
+			Results:    results,
+		}},
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type converter struct {
+	root    string
+	rules   []ReportingDescriptor
+	indexes map[string]int
+	sources map[string][]byte
+}
+
+// addRule describes the rule, rules without a code are identified by the ID.
+func (c *converter) addRule(analyzer string, rule rules.Rule) int {
+	descriptor := ReportingDescriptor{
+		ID:         rule.ID,
+		Properties: map[string]string{"analyzer": analyzer},
+	}
+
+	if rule.Code != "" {
+		descriptor.ID = rule.Code
+		descriptor.Name = rule.ID
+		descriptor.HelpURI = rules.URL(rule.Code)
+	}
+
+	if rule.Doc != "" {
+		descriptor.ShortDescription = &Message{Text: rule.Doc}
+		descriptor.Help = &Message{Text: rule.Doc}
+	}
+
+	if rule.Rationale != "" {
+		descriptor.FullDescription = &Message{Text: rule.Rationale}
+		descriptor.Help = &Message{Text: strings.TrimSpace(rule.Doc + "\n\n" + rule.Rationale)}
+	}
+
+	c.indexes[descriptor.ID] = len(c.rules)
+	c.rules = append(c.rules, descriptor)
+	return c.indexes[descriptor.ID]
+}
+
+// result converts the issue, issues without a known rule code get a rule
+// named after the analyzer and the category.
+func (c *converter) result(issue driver.Issue) (Result, error) {
+	ruleID := issue.Code
+	if ruleID == "" {
+		ruleID = issue.Rule()
+	}
+
+	index, ok := c.indexes[ruleID]
+	if !ok {
+		index = c.addRule(issue.Analyzer, rules.Rule{ID: ruleID})
+	}
+
+	level := LevelError
+	if issue.IsWarning() {
+		level = LevelWarning
+	}
+
+	artifact, err := c.artifactLocation(issue.Start.Filename)
+	if err != nil {
+		return Result{}, err
+	}
+
+	region, err := c.region(issue.Start.Filename, issue.Start.Offset, issue.End.Offset)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{
+		RuleID:    ruleID,
+		RuleIndex: index,
+		Level:     level,
+		Message:   Message{Text: issue.Message},
+		Locations: []Location{{PhysicalLocation: PhysicalLocation{
+			ArtifactLocation: artifact,
+			Region:           region,
+		}}},
+		Properties: map[string]string{
+			"analyzer": issue.Analyzer,
+			"category": issue.Category,
+		},
+	}
+
+	for _, fix := range issue.Fixes {
+		converted, err := c.fix(fix)
+		if err != nil {
+			return Result{}, err
+		}
+
+		result.Fixes = append(result.Fixes, converted)
+	}
+
+	return result, nil
+}
+
+// fix groups the edits by file, SARIF requires a change per artifact.
+func (c *converter) fix(fix driver.Fix) (Fix, error) {
+	result := Fix{ArtifactChanges: []ArtifactChange{}}
+	if fix.Message != "" {
+		result.Description = &Message{Text: fix.Message}
+	}
+
+	changes := make(map[string]int)
+	for _, edit := range fix.Edits {
+		filename := edit.Start.Filename
+		index, ok := changes[filename]
+		if !ok {
+			artifact, err := c.artifactLocation(filename)
+			if err != nil {
+				return Fix{}, err
+			}
+
+			index = len(result.ArtifactChanges)
+			changes[filename] = index
+			result.ArtifactChanges = append(
+				result.ArtifactChanges,
+				ArtifactChange{ArtifactLocation: artifact},
+			)
+		}
+
+		region, err := c.region(filename, edit.Start.Offset, edit.End.Offset)
+		if err != nil {
+			return Fix{}, err
+		}
+
+		replacement := Replacement{DeletedRegion: region}
+		if edit.NewText != "" {
+			replacement.InsertedContent = &ArtifactContent{Text: edit.NewText}
+		}
+
+		change := &result.ArtifactChanges[index]
+		change.Replacements = append(change.Replacements, replacement)
+	}
+
+	return result, nil
+}
+
+func (c *converter) artifactLocation(filename string) (ArtifactLocation, error) {
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return ArtifactLocation{}, err
+	}
+
+	path, err := filepath.Rel(c.root, absFilename)
+	if err != nil || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return ArtifactLocation{URI: fileURI(absFilename)}, nil
+	}
+
+	return ArtifactLocation{
+		URI:       (&url.URL{Path: filepath.ToSlash(path)}).String(),
+		URIBaseID: SrcRoot,
+	}, nil
+}
+
+// region converts byte offsets to lines and columns using the source, as
+// SARIF columns are not byte based.
+func (c *converter) region(filename string, start int, end int) (Region, error) {
+	src, ok := c.sources[filename]
+	if !ok {
+		var err error
+		src, err = os.ReadFile(filename)
+		if err != nil {
+			return Region{}, err
+		}
+
+		c.sources[filename] = src
+	}
+
+	start = min(start, len(src))
+	end = min(max(end, start), len(src))
+	startLine, startColumn := lineColumn(src, start)
+	endLine, endColumn := lineColumn(src, end)
+	return Region{
+		StartLine:   startLine,
+		StartColumn: startColumn,
+		EndLine:     endLine,
+		EndColumn:   endColumn,
+		ByteOffset:  start,
+		ByteLength:  end - start,
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func lineColumn(src []byte, offset int) (int, int) {
+	prefix := src[:offset]
+	lineStart := bytes.LastIndexByte(prefix, '\n') + 1
+	return 1 + bytes.Count(prefix, []byte("\n")), 1 + utf8.RuneCount(prefix[lineStart:])
+}
+
+func fileURI(filename string) string {
+	path := filepath.ToSlash(filename)
+	if !strings.HasPrefix(path, "/") {
+		// Windows paths start with a volume name.
+		path = "/" + path
+	}
+
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// directoryURI ends with a slash, as SARIF requires for base URIs.
+func directoryURI(dir string) string {
+	uri := fileURI(dir)
+	if !strings.HasSuffix(uri, "/") {
+		uri += "/"
+	}
+
+	return uri
+}
@@ -0,0 +1,93 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+	"github.com/jkuradobery/nbs-go-lint/line_breaks_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/separator_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/testcommon"
+)
+
+func TestRoundTrip(t *testing.T) {
+	dir := filepath.Join(testcommon.TestdataDir(t), "src", "example")
+	pkgs, err := driver.Load(dir, ".")
+	require.NoError(t, err)
+
+	analyzers := []*analysis.Analyzer{
+		line_breaks_analyzer.LineBreakAfterRbracket(),
+		separator_analyzer.SeparatorAnalyzer(),
+	}
+	issues, err := driver.Run(analyzers, pkgs)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	log, err := New(dir, analyzers, issues)
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	require.NoError(t, log.Write(&buffer))
+
+	var decoded Log
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	require.Equal(t, Version, decoded.Version)
+	require.Len(t, decoded.Runs, 1)
+
+	run := decoded.Runs[0]
+	require.Len(t, run.Results, len(issues))
+	for i, result := range run.Results {
+		issue := issues[i]
+		require.Equal(t, issue.Analyzer, result.Properties["analyzer"])
+		require.Equal(t, issue.Category, result.Properties["category"])
+		require.Equal(t, issue.Code, result.RuleID)
+		descriptor := run.Tool.Driver.Rules[result.RuleIndex]
+		require.Equal(t, issue.Code, descriptor.ID)
+		require.Equal(t, rules.URL(issue.Code), descriptor.HelpURI)
+		require.NotNil(t, descriptor.FullDescription)
+		require.Equal(t, issue.Message, result.Message.Text)
+		require.Equal(t, LevelError, result.Level)
+		require.Len(t, result.Fixes, len(issue.Fixes))
+
+		location := result.Locations[0].PhysicalLocation
+		require.Equal(t, "example.go", location.ArtifactLocation.URI)
+		require.Equal(t, SrcRoot, location.ArtifactLocation.URIBaseID)
+		require.Equal(t, issue.Start.Line, location.Region.StartLine)
+		require.Equal(t, issue.Start.Column, location.Region.StartColumn)
+		require.Equal(t, issue.End.Line, location.Region.EndLine)
+		require.Equal(t, issue.Start.Offset, location.Region.ByteOffset)
+	}
+
+	require.Equal(t, "line_breaks", run.Results[0].Properties["category"])
+	require.Equal(t, line_breaks_analyzer.CodeLineBreakAfterRbrace, run.Results[0].RuleID)
+	require.Equal(
+		t,
+		line_breaks_analyzer.RuleLineBreakAfterRbrace,
+		run.Tool.Driver.Rules[run.Results[0].RuleIndex].Name,
+	)
+	require.Equal(t, "separator", run.Results[1].Properties["category"])
+	require.Equal(t, separator_analyzer.CodeSeparatorAtTheEnd, run.Results[1].RuleID)
+
+	edit := issues[0].Fixes[0].Edits[0]
+	replacement := run.Results[0].Fixes[0].ArtifactChanges[0].Replacements[0]
+	require.Equal(t, edit.Start.Line, replacement.DeletedRegion.StartLine)
+	require.Equal(t, edit.Start.Offset, replacement.DeletedRegion.ByteOffset)
+	require.Equal(t, edit.NewText, replacement.InsertedContent.Text)
+}
+
+func TestLineColumn(t *testing.T) {
+	src := []byte("package ä\n\nvar ü = 1\n")
+	line, column := lineColumn(src, bytes.Index(src, []byte("=")))
+	require.Equal(t, 3, line)
+	require.Equal(t, 7, column)
+
+	line, column = lineColumn(src, bytes.Index(src, []byte("ä"))+len("ä"))
+	require.Equal(t, 1, line)
+	require.Equal(t, 10, column)
+}
@@ -0,0 +1,14 @@
+package example
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func first() {
+	if true {
+		fmt.Println("first")
+	}
+	fmt.Println("done")
+}
+
+////////////////////////////////////////////////////////////////////////////////
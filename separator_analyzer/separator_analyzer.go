@@ -1,30 +1,40 @@
 package separator_analyzer
 
 import (
+	"embed"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"log"
 	"slices"
 	"strings"
 
 	set "github.com/deckarep/golang-set/v2"
 	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
 )
 
+////////////////////////////////////////////////////////////////////////////////
+
 // between comment and separator can also be one line
 // If comment contains several "/" it should be treated as a separator for which
 // length != 80 is not allowed
 
-////////////////////////////////////////////////////////////////////////////////
-
 const Separator = "////////////////////////////////////////////////////////////////////////////////"
 const emptyReceiver = "emptyReceiver"
-const analyzerCategory = "separator"
+const Category = "separator"
 const MixingPublicAndPrivate = "Mixing public and private methods in the same group is not allowed"
 const MixingTestingAndCode = "Mixing testing and code methods in the same group is not allowed"
 const MixingMethodsWithIncorrectReceiverFormat = "Mixing methods with different receivers in the same group is not allowed %s"
 const SingleInterfaceOrStructMessage = "Only one interface or struct declaration is allowed between separators"
+const MalformedSeparatorMessage = "Malformed separator, it should be exactly 80 '/' characters"
+const GofmtSeparatorMessage = "Separator is mangled by gofmt, it should be exactly 80 '/' characters without spaces"
+
+// minSeparatorLength is the length of the shortest run of slashes (including
+// the leading "//") which is treated as a malformed separator.
+const minSeparatorLength = 10
 
 ////////////////////////////////////////////////////////////////////////////////
 
@@ -41,8 +51,8 @@ func getOriginalCommentText(
 	var builder strings.Builder
 	for _, comment := range commentGroup.List {
 		// Get the position information
-		startPos := fileset.Position(comment.Slash)
-		endPos := fileset.Position(comment.End())
+		startPos := fileset.PositionFor(comment.Slash, false)
+		endPos := fileset.PositionFor(comment.End(), false)
 
 		// Extract the original text
 		originalText := data[startPos.Offset:endPos.Offset]
@@ -52,6 +62,133 @@ func getOriginalCommentText(
 	return builder.String()
 }
 
+// splitSeparatorLike returns the number of slashes the line comment starts
+// with, whether there are spaces after the leading "//" as gofmt inserts them
+// and the text after the slashes.
+func splitSeparatorLike(text string) (int, bool, string) {
+	if !strings.HasPrefix(text, "//") {
+		return 0, false, text
+	}
+
+	rest := strings.TrimLeft(text[2:], " \t")
+	spaced := len(rest) != len(text)-2
+	withoutSlashes := strings.TrimLeft(rest, "/")
+	slashes := 2 + len(rest) - len(withoutSlashes)
+	return slashes, spaced, strings.TrimSpace(withoutSlashes)
+}
+
+// isMalformedSeparator reports whether the comment looks like a separator but
+// is not one. Another line comment after the separator is allowed.
+func isMalformedSeparator(text string) bool {
+	slashes, spaced, rest := splitSeparatorLike(text)
+	if slashes < minSeparatorLength {
+		return false
+	}
+
+	return slashes != len(Separator) ||
+		spaced ||
+		rest != "" && !strings.HasPrefix(rest, "//")
+}
+
+// isGofmtSeparator reports whether the comment is the separator rewritten by
+// gofmt to "// ////...", which happens when it is glued to a declaration.
+// Such comments are separators, but are reported as malformed.
+func isGofmtSeparator(text string) bool {
+	slashes, spaced, rest := splitSeparatorLike(text)
+	return slashes == len(Separator) &&
+		spaced &&
+		(rest == "" || strings.HasPrefix(rest, "//"))
+}
+
+// findSeparators returns the comment groups which separate sections.
+func findSeparators(
+	fileset *token.FileSet,
+	file *ast.File,
+	data []byte,
+	malformedSeparators []*ast.Comment,
+) []*ast.CommentGroup {
+
+	return Filter(
+		file.Comments,
+		func(group *ast.CommentGroup) bool {
+			text := getOriginalCommentText(fileset, group, data)
+			// Malformed separators on their own lines are section
+			// boundaries, they are reported only by
+			// ForbiddenMalformedSeparators.
+			return strings.Contains(text, Separator) ||
+				slices.ContainsFunc(
+					group.List,
+					func(comment *ast.Comment) bool {
+						return isGofmtSeparator(comment.Text) ||
+							slices.Contains(malformedSeparators, comment)
+					},
+				)
+		},
+	)
+}
+
+// IsSectionRule reports whether the rule with the code checks a section as a
+// whole, so its issues depend on every line of the section.
+func IsSectionRule(code string) bool {
+	return code == CodeSectionEntities || code == CodeEmptySection
+}
+
+// Section returns the bounds of the section containing the position: the end
+// of the previous separator or the package clause and the start of the next
+// separator or the end of the file.
+func Section(
+	fileset *token.FileSet,
+	file *ast.File,
+	data []byte,
+	pos token.Pos,
+) (token.Pos, token.Pos) {
+
+	lines := strings.Split(string(data), "\n")
+	malformedSeparators := findMalformedSeparators(fileset, file, lines)
+	separators := findSeparators(fileset, file, data, malformedSeparators)
+	start := file.Package
+	end := file.End()
+	for _, separator := range separators {
+		if separator.End() <= pos {
+			start = separator.End()
+			continue
+		}
+
+		if separator.Pos() >= pos {
+			end = separator.Pos()
+			break
+		}
+	}
+
+	return start, end
+}
+
+// findMalformedSeparators returns separator-like comments written on their
+// own lines.
+func findMalformedSeparators(
+	fileset *token.FileSet,
+	file *ast.File,
+	lines []string,
+) []*ast.Comment {
+
+	var result []*ast.Comment
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if !isMalformedSeparator(comment.Text) {
+				continue
+			}
+
+			position := fileset.PositionFor(comment.Pos(), false)
+			before := lines[position.Line-1][:position.Column-1]
+			if strings.TrimSpace(before) == "" {
+				result = append(result, comment)
+			}
+		}
+	}
+
+	return result
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func Filter[T any](data []T, predicate func(T) bool) []T {
@@ -105,12 +242,8 @@ func (f *functionDeclarationStorage) Add(decl *ast.FuncDecl) {
 	if decl.Recv != nil && len(decl.Recv.List) > 0 {
 		// If the function has a receiver, we consider it as a method
 		// and use the receiver type as part of the key.
-		if ident, ok := decl.Recv.List[0].Type.(*ast.Ident); ok {
-			declarationType.receiver = ident.Name
-		} else if starExpr, ok := decl.Recv.List[0].Type.(*ast.StarExpr); ok {
-			if ident, ok := starExpr.X.(*ast.Ident); ok {
-				declarationType.receiver = ident.Name
-			}
+		if name := BaseTypeName(decl.Recv.List[0].Type); name != "" {
+			declarationType.receiver = name
 		}
 	}
 
@@ -197,17 +330,8 @@ func functionReturnsStruct(decl *ast.FuncDecl, structName string) bool {
 	}
 
 	for _, result := range decl.Type.Results.List {
-		switch t := result.Type.(type) {
-		case *ast.Ident:
-			if t.Name == structName {
-				return true
-			}
-		case *ast.StarExpr:
-			if ident, ok := t.X.(*ast.Ident); ok && ident.Name == structName {
-				if ident.Name == structName {
-					return true
-				}
-			}
+		if BaseTypeName(result.Type) == structName {
+			return true
 		}
 	}
 
@@ -259,12 +383,96 @@ func getReferencedStructType(decl ast.Expr) string {
 		return ""
 	}
 
-	ident, ok := compositeLit.Type.(*ast.Ident)
-	if !ok {
-		return ""
+	return BaseTypeName(compositeLit.Type)
+}
+
+// hasTypesInfo reports whether the package is type-checked, the info is empty
+// when the analyzer is loaded in the syntax mode or is run by nbsfmt.
+func hasTypesInfo(pass *analysis.Pass) bool {
+	return pass.TypesInfo != nil &&
+		len(pass.TypesInfo.Types) != 0 &&
+		pass.Pkg != nil
+}
+
+// functionConstructsStruct is the type-checked version of
+// functionReturnsStruct. The function is a constructor if one of its results
+// or one of its returned values is the struct or a pointer to it, so values
+// built by new(T) or by helpers and returned as interfaces are detected.
+func functionConstructsStruct(
+	info *types.Info,
+	decl *ast.FuncDecl,
+	structType *types.TypeName,
+) bool {
+
+	if decl.Type.Results != nil {
+		for _, result := range decl.Type.Results.List {
+			if isStructOrPointer(info.TypeOf(result.Type), structType) {
+				return true
+			}
+		}
+	}
+
+	if decl.Body == nil {
+		return false
 	}
 
-	return ident.Name
+	constructs := false
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			// Returns of closures are not returns of the function.
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range node.Results {
+				constructs = constructs ||
+					isStructOrPointer(info.TypeOf(result), structType)
+			}
+		}
+
+		return !constructs
+	})
+
+	return constructs
+}
+
+// isStructOrPointer reports whether the type is the struct, a pointer to it,
+// an instance of the generic struct or a tuple containing one of them.
+func isStructOrPointer(t types.Type, structType *types.TypeName) bool {
+	if tuple, ok := t.(*types.Tuple); ok {
+		for i := 0; i < tuple.Len(); i++ {
+			if isStructOrPointer(tuple.At(i).Type(), structType) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if pointer, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Origin().Obj() == structType
+}
+
+// BaseTypeName returns the name of the type without pointers and type
+// arguments, e.g. "Set" for *Set[T], or an empty string for other types.
+func BaseTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return BaseTypeName(t.X)
+	case *ast.ParenExpr:
+		return BaseTypeName(t.X)
+	case *ast.IndexExpr:
+		return BaseTypeName(t.X)
+	case *ast.IndexListExpr:
+		return BaseTypeName(t.X)
+	}
+
+	return ""
 }
 
 func getVariableTypesByName(decl *ast.FuncDecl) map[string]string {
@@ -298,39 +506,240 @@ func getVariableTypesByName(decl *ast.FuncDecl) map[string]string {
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func SeparatorAnalyzer() *analysis.Analyzer {
-	return &analysis.Analyzer{
+const (
+	RuleSeparatorAtTheEnd           = "separator-at-the-end"
+	RuleSeparatorBeforeImports      = "separator-before-imports"
+	RuleSeparatorInMultilineComment = "separator-in-multiline-comment"
+	RuleSeparatorOverCode           = "separator-over-code"
+	RuleEmptyLinesAroundSeparator   = "empty-lines-around-separator"
+	RuleEmptySection                = "empty-section"
+	RuleSeparatorAfterPackage       = "separator-after-package"
+	RuleSeparatorAfterImports       = "separator-after-imports"
+	RuleSectionEntities             = "section-entities"
+	RuleMalformedSeparator          = "malformed-separator"
+)
+
+const (
+	CodeSeparatorAtTheEnd           = "NBS-SEP-001"
+	CodeSeparatorBeforeImports      = "NBS-SEP-002"
+	CodeSeparatorInMultilineComment = "NBS-SEP-003"
+	CodeSeparatorOverCode           = "NBS-SEP-004"
+	CodeEmptyLinesAroundSeparator   = "NBS-SEP-005"
+	CodeEmptySection                = "NBS-SEP-006"
+	CodeSeparatorAfterPackage       = "NBS-SEP-007"
+	CodeSeparatorAfterImports       = "NBS-SEP-008"
+	CodeSectionEntities             = "NBS-SEP-009"
+	CodeMalformedSeparator          = "NBS-SEP-010"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type separatorCheck struct {
+	rule rules.Rule
+	run  func(s *SeparatorAnalysis)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var separatorChecks = []separatorCheck{
+	{
+		rule: rules.Rule{
+			ID:   RuleSeparatorAtTheEnd,
+			Code: CodeSeparatorAtTheEnd,
+			Doc:  "The separator is forbidden at the end of the file.",
+			Rationale: "A separator opens a section, at the end of the file it separates " +
+				"nothing.",
+		},
+		run: (*SeparatorAnalysis).ForbiddenSeparatorAtTheEnd,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleSeparatorBeforeImports,
+			Code: CodeSeparatorBeforeImports,
+			Doc:  "The separator is forbidden before the package declaration and imports.",
+			Rationale: "The package clause and the imports form the header of the file, " +
+				"sections start after them.",
+		},
+		run: (*SeparatorAnalysis).ForbiddenSeparatorBeforeImports,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleSeparatorInMultilineComment,
+			Code: CodeSeparatorInMultilineComment,
+			Doc:  "The separator is forbidden inside a multiline comment.",
+			Rationale: "A separator glued to a comment is read as a part of the comment " +
+				"and gofmt mangles it.",
+		},
+		run: (*SeparatorAnalysis).ForbiddenMultilineComments,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleSeparatorOverCode,
+			Code: CodeSeparatorOverCode,
+			Doc:  "The separator is forbidden inside or next to code.",
+			Rationale: "A separator next to code splits a declaration instead of " +
+				"separating declarations.",
+		},
+		run: (*SeparatorAnalysis).ForbiddenSeparatorOverCode,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleEmptyLinesAroundSeparator,
+			Code: CodeEmptyLinesAroundSeparator,
+			Doc:  "There should be exactly one empty line before and after the separator.",
+			Rationale: "Exactly one empty line on each side keeps separators visible and " +
+				"files uniform, so diffs do not shuffle empty lines.",
+		},
+		run: (*SeparatorAnalysis).EmptyLinesAroundSeparator,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleEmptySection,
+			Code: CodeEmptySection,
+			Doc:  "There should be declarations between two consecutive separators.",
+			Rationale: "An empty section is a leftover of moved or deleted code and only " +
+				"adds noise.",
+		},
+		run: (*SeparatorAnalysis).NoDeclarationsBetweenTwoSeparators,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleSeparatorAfterPackage,
+			Code: CodeSeparatorAfterPackage,
+			Doc:  "If the file has no imports, the separator should be placed after the package declaration.",
+			Rationale: "The first section of a file without imports starts after the " +
+				"package clause, like it starts after the imports in other files.",
+		},
+		run: (*SeparatorAnalysis).CheckSeparatorAfterPackageForMissingImport,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleSeparatorAfterImports,
+			Code: CodeSeparatorAfterImports,
+			Doc:  "The separator is required after the imports, exactly one empty line after the last import.",
+			Rationale: "The separator after the imports marks where the code starts, " +
+				"every file has the same layout.",
+		},
+		run: (*SeparatorAnalysis).CheckSeparatorAfterImports,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleSectionEntities,
+			Code: CodeSectionEntities,
+			Doc:  "Each section should contain a single logical entity: a struct with its constructor and methods, an interface, a group of functions, types, vars or consts.",
+			Rationale: "A section is a unit of reading: a type with its constructors and " +
+				"methods or a group of related declarations, so readers can skip " +
+				"whole sections they are not interested in.",
+		},
+		run: (*SeparatorAnalysis).CheckSeparatorGroupsCorrectEntities,
+	},
+	{
+		rule: rules.Rule{
+			ID:   RuleMalformedSeparator,
+			Code: CodeMalformedSeparator,
+			Doc:  "Separator-like comments of another length or with text after the slashes are forbidden.",
+			Rationale: "Separators are found by their exact form, comments which look like " +
+				"separators but differ are not recognized by readers and tools.",
+		},
+		run: (*SeparatorAnalysis).ForbiddenMalformedSeparators,
+	},
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed testdata/src/explain
+var examples embed.FS
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Rules() []rules.Rule {
+	result := make([]rules.Rule, 0, len(separatorChecks))
+	for _, check := range separatorChecks {
+		result = append(result, check.rule)
+	}
+
+	return rules.WithExamples(examples, result)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Settings configure SeparatorAnalyzer, they are decoded from the
+// golangci-lint plugin configuration.
+type Settings struct {
+	rules.Toggle
+
+	// Rules maps rule IDs (or "all") to their severity.
+	Rules map[string]rules.Severity `json:"rules,omitempty"`
+	// TypeCheckedConstructors makes the analyzer detect constructors of
+	// structs with type information, which requires the package to be
+	// type-checked.
+	TypeCheckedConstructors bool `json:"type-checked-constructors,omitempty"`
+}
+
+func (s Settings) Validate() error {
+	return rules.NewRegistry(Rules()...).Configure(s.Rules)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func SeparatorAnalyzer(
+	options ...rules.Option[Settings],
+) *analysis.Analyzer {
+
+	settings := rules.ApplyOptions(options...)
+
+	registry := rules.NewRegistry(Rules()...)
+	configurationErr := registry.Configure(settings.Rules)
+
+	analyzer := &analysis.Analyzer{
 		Name: "SeparatorAnalyzer",
 		Doc:  "Checks if 80 lines 'otbivka' separates logical entities",
+		URL:  rules.DocumentationURL,
 		Run: func(pass *analysis.Pass) (any, error) {
+			if configurationErr != nil {
+				return nil, configurationErr
+			}
+
 			for _, file := range pass.Files {
 				separatorAnalysis := NewSeparatorAnalysis(pass, file)
-				separatorAnalysis.ForbiddenSeparatorAtTheEnd()
-				separatorAnalysis.ForbiddenSeparatorBeforeImports()
-				separatorAnalysis.ForbiddenMultilineComments()
-				separatorAnalysis.ForbiddenSeparatorOverCode()
-				separatorAnalysis.EmptyLinesAroundSeparator()
-				separatorAnalysis.NoDeclarationsBetweenTwoSeparators()
-				separatorAnalysis.CheckSeparatorAfterPackageForMissingImport()
-				separatorAnalysis.CheckSeparatorGroupsCorrectEntities()
+				separatorAnalysis.registry = registry
+				separatorAnalysis.typeCheckedConstructors = settings.TypeCheckedConstructors
+				for _, check := range separatorChecks {
+					if registry.IsEnabled(check.rule.ID) {
+						check.run(&separatorAnalysis)
+					}
+				}
 			}
 
 			return nil, nil
 		},
 	}
+	registry.RegisterFlags(&analyzer.Flags)
+	analyzer.Flags.BoolVar(
+		&settings.TypeCheckedConstructors,
+		"type-checked-constructors",
+		settings.TypeCheckedConstructors,
+		"detect constructors of structs with type information",
+	)
+
+	return analyzer
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
 type SeparatorAnalysis struct {
 	pass                 *analysis.Pass
+	registry             *rules.Registry
 	fileset              *token.FileSet
 	file                 *ast.File
 	topLevelDeclarations []ast.Decl
 	imports              []*ast.ImportSpec
 	separators           []*ast.CommentGroup
+	malformedSeparators  []*ast.Comment
 	data                 []byte
 	lines                []string
+	// typeCheckedConstructors is set by SeparatorAnalyzer from the settings.
+	typeCheckedConstructors bool
 }
 
 func NewSeparatorAnalysis(
@@ -364,16 +773,14 @@ func NewSeparatorAnalysis(
 		},
 	)
 
-	data, err := pass.ReadFile(pass.Fset.Position(file.Pos()).Filename)
+	filename := pass.Fset.PositionFor(file.Pos(), false).Filename
+	data, err := pass.ReadFile(filename)
 	if err != nil {
-		log.Fatalf(
-			"Error reading file %v: %v",
-			pass.Fset.Position(file.Pos()).Filename,
-			err,
-		)
+		log.Fatalf("Error reading file %v: %v", filename, err)
 	}
 
 	lines := strings.Split(string(data), "\n")
+	malformedSeparators := findMalformedSeparators(pass.Fset, file, lines)
 	return SeparatorAnalysis{
 		pass:                 pass,
 		fileset:              pass.Fset,
@@ -381,13 +788,7 @@ func NewSeparatorAnalysis(
 		topLevelDeclarations: topLevelDeclarations,
 		separators: slices.SortedFunc(
 			slices.Values(
-				Filter(
-					file.Comments,
-					func(group *ast.CommentGroup) bool {
-						text := getOriginalCommentText(pass.Fset, group, data)
-						return strings.Contains(text, Separator)
-					},
-				),
+				findSeparators(pass.Fset, file, data, malformedSeparators),
 			),
 			func(group *ast.CommentGroup, group2 *ast.CommentGroup) int {
 				return comparator(group, group2)
@@ -399,8 +800,9 @@ func NewSeparatorAnalysis(
 				return comparator(spec, spec2)
 			},
 		),
-		data:  data,
-		lines: lines,
+		malformedSeparators: malformedSeparators,
+		data:                data,
+		lines:               lines,
 	}
 }
 
@@ -420,11 +822,12 @@ func (s *SeparatorAnalysis) ForbiddenSeparatorAtTheEnd() {
 	lastSeparator := s.separators[len(s.separators)-1]
 	lastDeclaration := s.topLevelDeclarations[len(s.topLevelDeclarations)-1]
 	if lastSeparator.Pos() > lastDeclaration.End() {
-		s.pass.Report(analysis.Diagnostic{
-			Pos:      lastSeparator.Pos(),
-			End:      lastSeparator.End(),
-			Category: analyzerCategory,
-			Message:  "Separators at the end of the file are not allowed",
+		s.report(RuleSeparatorAtTheEnd, analysis.Diagnostic{
+			Pos:            lastSeparator.Pos(),
+			End:            lastSeparator.End(),
+			Category:       Category,
+			Message:        "Separators at the end of the file are not allowed",
+			SuggestedFixes: s.removeSeparatorFix(lastSeparator),
 		})
 	}
 }
@@ -438,11 +841,12 @@ func (s *SeparatorAnalysis) ForbiddenSeparatorBeforeImports() {
 
 	if len(s.imports) == 0 {
 		if firstSeparator.End() <= s.file.Package {
-			s.pass.Report(analysis.Diagnostic{
-				Pos:      firstSeparator.Pos(),
-				End:      firstSeparator.End(),
-				Category: analyzerCategory,
-				Message:  "Separator is not allowed before package declaration",
+			s.report(RuleSeparatorBeforeImports, analysis.Diagnostic{
+				Pos:            firstSeparator.Pos(),
+				End:            firstSeparator.End(),
+				Category:       Category,
+				Message:        "Separator is not allowed before package declaration",
+				SuggestedFixes: s.removeSeparatorFix(firstSeparator),
 			})
 		}
 		return
@@ -450,25 +854,27 @@ func (s *SeparatorAnalysis) ForbiddenSeparatorBeforeImports() {
 
 	lastImport := s.imports[len(s.imports)-1]
 	if firstSeparator.Pos() < lastImport.End() {
-		s.pass.Report(analysis.Diagnostic{
-			Pos:      firstSeparator.Pos(),
-			End:      firstSeparator.End(),
-			Category: analyzerCategory,
-			Message:  "Separator is not allowed before imports",
+		s.report(RuleSeparatorBeforeImports, analysis.Diagnostic{
+			Pos:            firstSeparator.Pos(),
+			End:            firstSeparator.End(),
+			Category:       Category,
+			Message:        "Separator is not allowed before imports",
+			SuggestedFixes: s.removeSeparatorFix(firstSeparator),
 		})
 	}
 }
 
 func (s *SeparatorAnalysis) ForbiddenMultilineComments() {
 	for _, group := range s.separators {
-		startLine := s.fileset.Position(group.Pos()).Line
-		endLine := s.fileset.Position(group.End()).Line
+		startLine := s.position(group.Pos()).Line
+		endLine := s.position(group.End()).Line
 		if endLine-startLine > 0 {
-			s.pass.Report(analysis.Diagnostic{
-				Pos:      group.Pos(),
-				End:      group.End(),
-				Category: analyzerCategory,
-				Message:  "Separator is not allowed a part of multiline comment",
+			s.report(RuleSeparatorInMultilineComment, analysis.Diagnostic{
+				Pos:            group.Pos(),
+				End:            group.End(),
+				Category:       Category,
+				Message:        "Separator is not allowed a part of multiline comment",
+				SuggestedFixes: s.multilineCommentFix(group),
 			})
 		}
 	}
@@ -483,10 +889,10 @@ func (s *SeparatorAnalysis) ForbiddenSeparatorOverCode() {
 		// MxN instead of M + N complexity for code simplicity
 		for _, declaration := range s.topLevelDeclarations {
 			if s.nodesOverlap(separator, declaration) {
-				s.pass.Report(analysis.Diagnostic{
+				s.report(RuleSeparatorOverCode, analysis.Diagnostic{
 					Pos:      separator.Pos(),
 					End:      separator.End(),
-					Category: analyzerCategory,
+					Category: Category,
 					Message:  "Separator is not allowed over code",
 				})
 			}
@@ -539,11 +945,12 @@ func (s *SeparatorAnalysis) EmptyLinesAroundSeparator() {
 			}
 		}
 
-		s.pass.Report(analysis.Diagnostic{
-			Pos:      separator.Pos(),
-			End:      separator.End(),
-			Category: analyzerCategory,
-			Message:  "Each Separator should be surrounded by exactly one empty line",
+		s.report(RuleEmptyLinesAroundSeparator, analysis.Diagnostic{
+			Pos:            separator.Pos(),
+			End:            separator.End(),
+			Category:       Category,
+			Message:        "Each Separator should be surrounded by exactly one empty line",
+			SuggestedFixes: s.emptyLinesAroundSeparatorFix(separator),
 		})
 	}
 }
@@ -565,10 +972,10 @@ func (s *SeparatorAnalysis) NoDeclarationsBetweenTwoSeparators() {
 		}
 
 		if !declarationFound {
-			s.pass.Report(analysis.Diagnostic{
+			s.report(RuleEmptySection, analysis.Diagnostic{
 				Pos:      currentSeparator.End(),
 				End:      nextSeparator.Pos(),
-				Category: analyzerCategory,
+				Category: Category,
 				Message:  "Empty section detected: no declarations found between consecutive separators",
 			})
 		}
@@ -583,11 +990,12 @@ func (s *SeparatorAnalysis) CheckSeparatorAfterPackageForMissingImport() {
 	const message = "Missing Separator after package " +
 		"declaration when no imports present"
 	if len(s.separators) == 0 {
-		s.pass.Report(analysis.Diagnostic{
-			Pos:      s.file.Package,
-			End:      s.file.Package,
-			Category: analyzerCategory,
-			Message:  message,
+		s.report(RuleSeparatorAfterPackage, analysis.Diagnostic{
+			Pos:            s.file.Package,
+			End:            s.file.Package,
+			Category:       Category,
+			Message:        message,
+			SuggestedFixes: s.separatorAfterPackageFix(),
 		})
 		return
 	}
@@ -596,20 +1004,78 @@ func (s *SeparatorAnalysis) CheckSeparatorAfterPackageForMissingImport() {
 	packageLine := s.position(s.file.Package).Line
 	separatorLine := s.position(firstSeparator.Pos()).Line
 	if separatorLine != packageLine+2 {
-		s.pass.Report(
+		s.report(
+			RuleSeparatorAfterPackage,
 			analysis.Diagnostic{
-				Pos:      s.file.Package,
-				End:      s.file.Package,
-				Category: analyzerCategory,
-				Message:  message,
+				Pos:            s.file.Package,
+				End:            s.file.Package,
+				Category:       Category,
+				Message:        message,
+				SuggestedFixes: s.separatorAfterPackageFix(),
 			},
 		)
 	}
 }
 
+func (s *SeparatorAnalysis) CheckSeparatorAfterImports() {
+	var lastImport ast.Decl
+	var firstDeclaration ast.Decl
+	for _, declaration := range s.topLevelDeclarations {
+		if genDecl, ok := declaration.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			lastImport = declaration
+		} else if firstDeclaration == nil {
+			firstDeclaration = declaration
+		}
+	}
+
+	if lastImport == nil || firstDeclaration == nil {
+		return
+	}
+
+	importLine := s.position(lastImport.End()).Line
+	for _, separator := range s.separators {
+		if separator.Pos() < lastImport.End() {
+			continue
+		}
+
+		if separator.Pos() < firstDeclaration.Pos() &&
+			s.position(separator.Pos()).Line == importLine+2 {
+
+			return
+		}
+
+		break
+	}
+
+	s.report(RuleSeparatorAfterImports, analysis.Diagnostic{
+		Pos:            firstDeclaration.Pos(),
+		End:            firstDeclaration.Pos(),
+		Category:       Category,
+		Message:        "Missing Separator after imports",
+		SuggestedFixes: s.separatorAfterImportsFix(lastImport),
+	})
+}
+
+func (s *SeparatorAnalysis) ForbiddenMalformedSeparators() {
+	for _, comment := range s.malformedSeparators {
+		message := MalformedSeparatorMessage
+		if isGofmtSeparator(comment.Text) {
+			message = GofmtSeparatorMessage
+		}
+
+		s.report(RuleMalformedSeparator, analysis.Diagnostic{
+			Pos:            comment.Pos(),
+			End:            comment.End(),
+			Category:       Category,
+			Message:        message,
+			SuggestedFixes: s.malformedSeparatorFix(comment),
+		})
+	}
+}
+
 func (s *SeparatorAnalysis) CheckSeparatorGroupsCorrectEntities() {
-	// Type alias groups, var groups, const groups, import groups should be separated
-	// by a single separator.
+	// Type alias groups, var groups, const groups should be separated
+	// by a single separator, imports are checked by CheckSeparatorAfterImports.
 	// Exactly one interface can be declared between two separators.
 	// Test functions should be separated by a single separator.
 	// Exactly one struct and its constructor and its private methods should be separated.
@@ -621,6 +1087,10 @@ func (s *SeparatorAnalysis) CheckSeparatorGroupsCorrectEntities() {
 
 		declarationsByTypeWithinBucket := make(map[token.Token][]ast.Decl)
 		for _, decl := range bucket {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+				continue
+			}
+
 			var tok token.Token
 			switch d := decl.(type) {
 			case *ast.GenDecl:
@@ -629,11 +1099,12 @@ func (s *SeparatorAnalysis) CheckSeparatorGroupsCorrectEntities() {
 				tok = token.FUNC
 			default:
 				message := "Unknown declaration type found in bucket, might be a bug"
-				s.pass.Report(
+				s.report(
+					RuleSectionEntities,
 					analysis.Diagnostic{
 						Pos:      decl.Pos(),
 						End:      decl.End(),
-						Category: analyzerCategory,
+						Category: Category,
 						Message:  message,
 					},
 				)
@@ -655,6 +1126,38 @@ func (s *SeparatorAnalysis) CheckSeparatorGroupsCorrectEntities() {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+func (s *SeparatorAnalysis) report(
+	ruleID string,
+	diagnostic analysis.Diagnostic,
+) {
+
+	if s.registry == nil {
+		s.pass.Report(diagnostic)
+		return
+	}
+
+	s.registry.Report(s.pass, ruleID, diagnostic)
+}
+
+// isConstructor uses type information if it is requested and available,
+// otherwise constructors are detected syntactically.
+func (s *SeparatorAnalysis) isConstructor(
+	decl *ast.FuncDecl,
+	structName string,
+) bool {
+
+	if !s.typeCheckedConstructors || !hasTypesInfo(s.pass) {
+		return functionReturnsStruct(decl, structName)
+	}
+
+	structType, ok := s.pass.Pkg.Scope().Lookup(structName).(*types.TypeName)
+	if !ok {
+		return false
+	}
+
+	return functionConstructsStruct(s.pass.TypesInfo, decl, structType)
+}
+
 func (s *SeparatorAnalysis) nodesOverlap(node ast.Node, node2 ast.Node) bool {
 	if s.position(node.Pos()).Line > s.position(node2.End()).Line {
 		return false
@@ -665,8 +1168,9 @@ func (s *SeparatorAnalysis) nodesOverlap(node ast.Node, node2 ast.Node) bool {
 	return true
 }
 
+// position ignores //line directives, as the result is used to index lines.
 func (s *SeparatorAnalysis) position(pos token.Pos) token.Position {
-	return s.fileset.Position(pos)
+	return s.fileset.PositionFor(pos, false)
 }
 
 func (s *SeparatorAnalysis) collectDeclarationsByBuckets() map[int][]ast.Decl {
@@ -722,10 +1226,9 @@ func (s *SeparatorAnalysis) processDeclarationsWithinBucket(
 	declarationsByTypeWithinBucket map[token.Token][]ast.Decl,
 ) {
 	singleDeclarationTypeRequired := map[token.Token]struct{}{
-		token.TYPE:   {},
-		token.VAR:    {},
-		token.CONST:  {},
-		token.IMPORT: {},
+		token.TYPE:  {},
+		token.VAR:   {},
+		token.CONST: {},
 	}
 	if len(declarationsByTypeWithinBucket) == 0 {
 		return
@@ -790,12 +1293,13 @@ func (s *SeparatorAnalysis) processDeclarationsWithinBucket(
 	for receiver, declarations := range storage.DeclarationsByReceiver() {
 		if receiver == emptyReceiver {
 			for _, decl := range declarations {
-				if !functionReturnsStruct(decl, structName) {
-					s.pass.Report(
+				if !s.isConstructor(decl, structName) {
+					s.report(
+						RuleSectionEntities,
 						analysis.Diagnostic{
 							Pos:      decl.Pos(),
 							End:      decl.End(),
-							Category: analyzerCategory,
+							Category: Category,
 							Message: fmt.Sprintf(
 								"Function which is not a constructor for struct '%s' is not allowed in the same group as struct '%s'",
 								decl.Name.Name,
@@ -807,11 +1311,12 @@ func (s *SeparatorAnalysis) processDeclarationsWithinBucket(
 			}
 		} else if receiver != structName {
 			for _, decl := range declarations {
-				s.pass.Report(
+				s.report(
+					RuleSectionEntities,
 					analysis.Diagnostic{
 						Pos:      decl.Pos(),
 						End:      decl.End(),
-						Category: analyzerCategory,
+						Category: Category,
 						Message: fmt.Sprintf(
 							"Method with receiver '%s' is not allowed in the same group as struct '%s'",
 							receiver,
@@ -827,10 +1332,10 @@ func (s *SeparatorAnalysis) processDeclarationsWithinBucket(
 func (s *SeparatorAnalysis) reportMultipleInterfacesOrStructs(
 	decls []ast.Decl,
 ) {
-	s.pass.Report(analysis.Diagnostic{
+	s.report(RuleSectionEntities, analysis.Diagnostic{
 		Pos:      decls[0].Pos(),
 		End:      decls[len(decls)-1].End(),
-		Category: analyzerCategory,
+		Category: Category,
 		Message:  SingleInterfaceOrStructMessage,
 	})
 }
@@ -845,10 +1350,10 @@ func (s *SeparatorAnalysis) reportVariousTypesBetweenSeparators(
 	}
 	for _, declarations := range declarationsByTypeWithinBucket {
 		for _, decl := range declarations {
-			s.pass.Report(analysis.Diagnostic{
+			s.report(RuleSectionEntities, analysis.Diagnostic{
 				Pos:      decl.Pos(),
 				End:      decl.End(),
-				Category: analyzerCategory,
+				Category: Category,
 				Message: fmt.Sprintf(
 					"Forbidden declarations within the same group: %s",
 					strings.Join(declTypeList, ", "),
@@ -883,11 +1388,12 @@ func (s *SeparatorAnalysis) reportMixingDifferentReceiver(
 		)
 		for _, decls := range receivers {
 			for _, decl := range decls {
-				s.pass.Report(
+				s.report(
+					RuleSectionEntities,
 					analysis.Diagnostic{
 						Pos:      decl.Pos(),
 						End:      decl.End(),
-						Category: analyzerCategory,
+						Category: Category,
 						Message:  message,
 					},
 				)
@@ -899,10 +1405,10 @@ func (s *SeparatorAnalysis) reportMixingDifferentReceiver(
 func (s *SeparatorAnalysis) reportMixingTestsWithCode(storage *functionDeclarationStorage) {
 	if decls := storage.MixedTestingAndCode(); len(decls) > 0 {
 		for _, decl := range decls {
-			s.pass.Report(analysis.Diagnostic{
+			s.report(RuleSectionEntities, analysis.Diagnostic{
 				Pos:      decl.Pos(),
 				End:      decl.End(),
-				Category: analyzerCategory,
+				Category: Category,
 				Message:  MixingTestingAndCode,
 			})
 		}
@@ -912,10 +1418,10 @@ func (s *SeparatorAnalysis) reportMixingTestsWithCode(storage *functionDeclarati
 func (s *SeparatorAnalysis) reportMixingPrivateAndPublic(storage *functionDeclarationStorage) {
 	if decls := storage.MixedPublicAndPrivate(); len(decls) > 0 {
 		for _, decl := range decls {
-			s.pass.Report(analysis.Diagnostic{
+			s.report(RuleSectionEntities, analysis.Diagnostic{
 				Pos:      decl.Pos(),
 				End:      decl.End(),
-				Category: analyzerCategory,
+				Category: Category,
 				Message:  MixingPublicAndPrivate,
 			})
 		}
@@ -943,11 +1449,12 @@ func (s *SeparatorAnalysis) getTokenForTopLevelDecl(
 
 	if len(decl.Specs) != 1 {
 		const message = "Type declaration should have exactly one spec"
-		s.pass.Report(
+		s.report(
+			RuleSectionEntities,
 			analysis.Diagnostic{
 				Pos:      decl.Pos(),
 				End:      decl.End(),
-				Category: analyzerCategory,
+				Category: Category,
 				Message:  message,
 			},
 		)
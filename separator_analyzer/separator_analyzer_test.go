@@ -1,19 +1,119 @@
 package separator_analyzer
 
 import (
+	"go/parser"
+	"go/token"
 	"testing"
 
+	"github.com/jkuradobery/nbs-go-lint/rules"
 	"github.com/jkuradobery/nbs-go-lint/testcommon"
 
+	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
 func TestSeparatorAnalyzer(t *testing.T) {
 	analysistest.TestData()
-	analysistest.Run(
+	analysistest.RunWithSuggestedFixes(
 		t,
 		testcommon.TestdataDir(t),
 		SeparatorAnalyzer(),
 		"example/",
 	)
 }
+
+func TestSeparatorAnalyzerRulesSettings(t *testing.T) {
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		SeparatorAnalyzer(rules.WithSettings(Settings{
+			Rules: map[string]rules.Severity{
+				rules.All:                 rules.SeverityOff,
+				RuleSeparatorAfterPackage: rules.SeverityWarning,
+			},
+		})),
+		"rules/",
+	)
+}
+
+func TestSeparatorAnalyzerRulesFlags(t *testing.T) {
+	analyzer := SeparatorAnalyzer()
+	require.NoError(t, analyzer.Flags.Parse([]string{
+		"-disable=all",
+		"-warn=" + RuleSeparatorAfterPackage,
+	}))
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		analyzer,
+		"rules/",
+	)
+
+	require.Error(t, analyzer.Flags.Set("enable", "no-such-rule"))
+}
+
+func TestSeparatorAnalyzerTypeCheckedConstructors(t *testing.T) {
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		SeparatorAnalyzer(rules.WithSettings(Settings{
+			TypeCheckedConstructors: true,
+		})),
+		"constructors/",
+	)
+}
+
+func TestSection(t *testing.T) {
+	src := "package example\n\n" +
+		Separator + "\n\n" +
+		"func a() {}\n\n" +
+		Separator + "\n\n" +
+		"func b() {}\n\n" +
+		"// " + Separator + "\n" +
+		"func c() {}\n"
+
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, "example.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	lines := func(pos token.Pos) (int, int) {
+		start, end := Section(fileset, file, []byte(src), pos)
+		return fileset.Position(start).Line, fileset.Position(end).Line
+	}
+
+	declarations := file.Decls
+	start, end := lines(declarations[0].Pos())
+	require.Equal(t, 3, start)
+	require.Equal(t, 7, end)
+
+	// The separator mangled by gofmt bounds the section too.
+	start, end = lines(declarations[1].Pos())
+	require.Equal(t, 7, start)
+	require.Equal(t, 11, end)
+
+	start, end = lines(declarations[2].Pos())
+	require.Equal(t, 11, start)
+	require.Equal(t, 12, end)
+}
+
+// TestSeparatorAnalyzerExamples checks every rule alone, as the bad examples
+// of some rules violate other rules too.
+func TestSeparatorAnalyzerExamples(t *testing.T) {
+	for _, rule := range Rules() {
+		t.Run(rule.Code, func(t *testing.T) {
+			analyzer := SeparatorAnalyzer(rules.WithSettings(Settings{
+				Rules: map[string]rules.Severity{
+					rules.All: rules.SeverityOff,
+					rule.ID:   rules.SeverityError,
+				},
+			}))
+
+			testcommon.RunExamples(
+				t,
+				testcommon.TestdataDir(t),
+				analyzer,
+				[]rules.Rule{rule},
+			)
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package separator_analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// lineIndex returns the index of the line in SeparatorAnalysis.lines, fixes
+// operate on whole lines.
+func (s *SeparatorAnalysis) lineIndex(pos token.Pos) int {
+	return s.position(pos).Line - 1
+}
+
+// lineStart returns the position of the first character of the line,
+// lines after the last one start at the end of the file.
+func (s *SeparatorAnalysis) lineStart(lineIndex int) token.Pos {
+	tokenFile := s.fileset.File(s.file.Pos())
+	if lineIndex >= tokenFile.LineCount() {
+		return token.Pos(tokenFile.Base() + tokenFile.Size())
+	}
+
+	return tokenFile.LineStart(lineIndex + 1)
+}
+
+func (s *SeparatorAnalysis) isEmptyLine(lineIndex int) bool {
+	return strings.TrimSpace(s.lines[lineIndex]) == ""
+}
+
+// previousNonEmptyLine returns -1 if there are only empty lines before.
+func (s *SeparatorAnalysis) previousNonEmptyLine(lineIndex int) int {
+	for i := lineIndex - 1; i >= 0; i-- {
+		if !s.isEmptyLine(i) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// nextNonEmptyLine returns len(s.lines) if there are only empty lines after.
+func (s *SeparatorAnalysis) nextNonEmptyLine(lineIndex int) int {
+	for i := lineIndex + 1; i < len(s.lines); i++ {
+		if !s.isEmptyLine(i) {
+			return i
+		}
+	}
+
+	return len(s.lines)
+}
+
+// ownsLines reports whether nothing but the comment group is written on the
+// lines it occupies and the group is not inside a declaration.
+func (s *SeparatorAnalysis) ownsLines(group *ast.CommentGroup) bool {
+	start := s.position(group.Pos())
+	end := s.position(group.End())
+	if strings.TrimSpace(s.lines[start.Line-1][:start.Column-1]) != "" {
+		return false
+	}
+
+	if strings.TrimSpace(s.lines[end.Line-1][end.Column-1:]) != "" {
+		return false
+	}
+
+	for _, declaration := range s.topLevelDeclarations {
+		if s.nodesOverlap(group, declaration) {
+			return false
+		}
+	}
+
+	return true
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (s *SeparatorAnalysis) removeSeparatorFix(
+	group *ast.CommentGroup,
+) []analysis.SuggestedFix {
+
+	if !s.ownsLines(group) {
+		return nil
+	}
+
+	previous := s.previousNonEmptyLine(s.lineIndex(group.Pos()))
+	next := s.nextNonEmptyLine(s.lineIndex(group.End()))
+
+	edit := analysis.TextEdit{
+		Pos:     s.lineStart(previous + 1),
+		End:     s.lineStart(next),
+		NewText: []byte("\n"),
+	}
+	if previous == -1 || next == len(s.lines) {
+		// Nothing is left at the beginning or at the end of the file.
+		edit.NewText = nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Remove separator",
+		TextEdits: []analysis.TextEdit{edit},
+	}}
+}
+
+// insertSeparatorFix puts the separator after the line surrounded by
+// exactly one empty line.
+func (s *SeparatorAnalysis) insertSeparatorFix(
+	lineIndex int,
+) []analysis.SuggestedFix {
+
+	next := s.nextNonEmptyLine(lineIndex)
+	if next == len(s.lines) {
+		// Separator at the end of the file is not allowed.
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: "Insert separator",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     s.lineStart(lineIndex + 1),
+			End:     s.lineStart(next),
+			NewText: []byte("\n" + Separator + "\n\n"),
+		}},
+	}}
+}
+
+// separatorAfterPackageFix moves the first separator right after the package
+// clause if only empty lines are between them, otherwise inserts a new one.
+func (s *SeparatorAnalysis) separatorAfterPackageFix() []analysis.SuggestedFix {
+	return s.separatorAfterLineFix(
+		s.lineIndex(s.file.Name.End()),
+		"Move separator after package clause",
+	)
+}
+
+// separatorAfterImportsFix does the same as separatorAfterPackageFix for the
+// line where the last import declaration ends.
+func (s *SeparatorAnalysis) separatorAfterImportsFix(
+	lastImport ast.Decl,
+) []analysis.SuggestedFix {
+
+	return s.separatorAfterLineFix(
+		s.lineIndex(lastImport.End()),
+		"Move separator after imports",
+	)
+}
+
+func (s *SeparatorAnalysis) separatorAfterLineFix(
+	lineIndex int,
+	message string,
+) []analysis.SuggestedFix {
+
+	var nextSeparator *ast.CommentGroup
+	for _, separator := range s.separators {
+		if s.lineIndex(separator.Pos()) > lineIndex {
+			nextSeparator = separator
+			break
+		}
+	}
+
+	if nextSeparator == nil {
+		return s.insertSeparatorFix(lineIndex)
+	}
+
+	separatorLine := s.lineIndex(nextSeparator.Pos())
+	if s.previousNonEmptyLine(separatorLine) != lineIndex ||
+		!s.ownsLines(nextSeparator) {
+
+		return s.insertSeparatorFix(lineIndex)
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: message,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     s.lineStart(lineIndex + 1),
+			End:     s.lineStart(separatorLine),
+			NewText: []byte("\n"),
+		}},
+	}}
+}
+
+func (s *SeparatorAnalysis) emptyLinesAroundSeparatorFix(
+	group *ast.CommentGroup,
+) []analysis.SuggestedFix {
+
+	if !s.ownsLines(group) {
+		return nil
+	}
+
+	first := s.lineIndex(group.Pos())
+	last := s.lineIndex(group.End())
+	if first != last {
+		// Multiline comments are fixed by ForbiddenMultilineComments.
+		return nil
+	}
+
+	edits := make([]analysis.TextEdit, 0, 2)
+	previous := s.previousNonEmptyLine(first)
+	if previous != -1 && first-previous != 2 {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     s.lineStart(previous + 1),
+			End:     s.lineStart(first),
+			NewText: []byte("\n"),
+		})
+	}
+
+	next := s.nextNonEmptyLine(last)
+	if next != len(s.lines) && next-last != 2 {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     s.lineStart(last + 1),
+			End:     s.lineStart(next),
+			NewText: []byte("\n"),
+		})
+	}
+
+	if len(edits) == 0 {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Surround separator with empty lines",
+		TextEdits: edits,
+	}}
+}
+
+// multilineCommentFix moves separators out of line comment groups and
+// replaces block comments containing nothing but separators.
+func (s *SeparatorAnalysis) multilineCommentFix(
+	group *ast.CommentGroup,
+) []analysis.SuggestedFix {
+
+	if !s.ownsLines(group) {
+		return nil
+	}
+
+	fix := func(text string) []analysis.SuggestedFix {
+		return []analysis.SuggestedFix{{
+			Message: "Split separator from multiline comment",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     group.Pos(),
+				End:     group.End(),
+				NewText: []byte(text),
+			}},
+		}}
+	}
+
+	if len(group.List) == 1 {
+		text := group.List[0].Text
+		if !strings.HasPrefix(text, "/*") {
+			return nil
+		}
+
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+		text = strings.ReplaceAll(text, Separator, "")
+		if strings.TrimSpace(text) != "" {
+			// Block comment has some text besides the separator.
+			return nil
+		}
+
+		return fix(Separator)
+	}
+
+	start := s.position(group.Pos())
+	indentation := s.lines[start.Line-1][:start.Column-1]
+
+	// Each separator becomes a paragraph of its own,
+	// consecutive separators are merged.
+	paragraphs := make([]string, 0, len(group.List))
+	lastIsSeparator := false
+	for index, comment := range group.List {
+		if strings.HasPrefix(comment.Text, "/*") {
+			return nil
+		}
+
+		isSeparator := strings.Contains(comment.Text, Separator) ||
+			isGofmtSeparator(comment.Text) ||
+			slices.Contains(s.malformedSeparators, comment)
+		switch {
+		case isSeparator && lastIsSeparator:
+			continue
+		case index > 0 && !isSeparator && !lastIsSeparator:
+			paragraphs[len(paragraphs)-1] += "\n" + indentation + comment.Text
+		default:
+			paragraphs = append(paragraphs, comment.Text)
+		}
+
+		lastIsSeparator = isSeparator
+	}
+
+	return fix(strings.Join(paragraphs, "\n\n"+indentation))
+}
+
+// malformedSeparatorFix replaces the comment with the separator, text after
+// the slashes is kept as a comment of its own.
+func (s *SeparatorAnalysis) malformedSeparatorFix(
+	comment *ast.Comment,
+) []analysis.SuggestedFix {
+
+	text := Separator
+	_, _, rest := splitSeparatorLike(comment.Text)
+	switch {
+	case strings.HasPrefix(rest, "//"):
+		text += " " + rest
+	case rest != "":
+		start := s.position(comment.Pos())
+		indentation := s.lines[start.Line-1][:start.Column-1]
+		text += "\n\n" + indentation + "// " + rest
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: "Replace with separator",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     comment.Pos(),
+			End:     comment.End(),
+			NewText: []byte(text),
+		}},
+	}}
+}
@@ -0,0 +1,77 @@
+package constructors
+
+import "errors"
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Alpha interface {
+	AlphaMethod()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Beta struct {
+	name string
+}
+
+func NewBeta() *Beta {
+	return new(Beta)
+}
+
+func (b *Beta) AlphaMethod() {}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Gamma struct {
+	name string
+}
+
+func NewGamma(name string) (Alpha, error) {
+	if name == "" {
+		return nil, errors.New("empty name")
+	}
+
+	if name == "default" {
+		return &Gamma{name: "gamma"}, nil
+	}
+
+	return buildGamma(name), nil
+}
+
+func buildGamma(name string) *Gamma {
+	return &Gamma{name: name}
+}
+
+func (g *Gamma) AlphaMethod() {}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Delta struct {
+	name string
+}
+
+func NewDelta(name string) Alpha {
+	delta := Delta{}
+	delta.name = name
+	return &delta
+}
+
+func (d *Delta) AlphaMethod() {}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Epsilon struct {
+	names []string
+}
+
+func NewEpsilon() *Epsilon {
+	return &Epsilon{}
+}
+
+func CountNames(names []string) int { // want `Function which is not a constructor for struct 'CountNames' is not allowed in the same group as struct 'Epsilon'`
+	count := func() *Epsilon {
+		return &Epsilon{names: names}
+	}
+
+	return len(count().names)
+}
@@ -1,24 +1,24 @@
-////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed before package declaration" "Empty section detected"
 
-package example
+package example // want "Missing Separator after package declaration when no imports present"
 
 func ExampleFunc3() int {
-	////////////////////////////////////////////////////////////////////////////////
+	//////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed over code" "Each Separator should be surrounded by exactly one empty line" "Empty section detected"
 
-	return 5 ////////////////////////////////////////////////////////////////////////////////
-	////////////////////////////////////////////////////////////////////////////////
+	return 5 //////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed over code" "Each Separator should be surrounded by exactly one empty line" "Empty section detected"
+	//////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed over code" "Each Separator should be surrounded by exactly one empty line"
 }
 
 var a = 5
 
 /*
-////////////////////////////////////////////////////////////////////////////////
-*/
+//////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed a part of multiline comment" "Each Separator should be surrounded by exactly one empty line"
+*/ // want "Empty section detected"
 
-////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed a part of multiline comment" "Each Separator should be surrounded by exactly one empty line"
 ////////////////////////////////////////////////////////////////////////////////
 
-type firstExampleStruct struct {
+type firstExampleStruct struct { // want "Only one interface or struct declaration is allowed between separators"
 }
 
 type SecondExampleStruct struct {
@@ -26,7 +26,7 @@ type SecondExampleStruct struct {
 
 ////////////////////////////////////////////////////////////////////////////////
 
-type A interface {
+type A interface { // want "Only one interface or struct declaration is allowed between separators"
 	B()
 }
 
@@ -36,18 +36,18 @@ type C interface {
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func (f firstExampleStruct) B() {}
+func (f firstExampleStruct) B() {} // want "Mixing methods with different receivers in the same group is not allowed"
 
-func (s SecondExampleStruct) D() {}
+func (s SecondExampleStruct) D() {} // want "Mixing methods with different receivers in the same group is not allowed"
 
 ////////////////////////////////////////////////////////////////////////////////
 
 func (f firstExampleStruct) E() {}
 
-func (f firstExampleStruct) f() {}
+func (f firstExampleStruct) f() {} // want "Mixing public and private methods in the same group is not allowed"
 
 ////////////////////////////////////////////////////////////////////////////////
 
 func firstFunc() {}
 
-func SecondFunc() {}
+func SecondFunc() {} // want "Mixing public and private methods in the same group is not allowed"
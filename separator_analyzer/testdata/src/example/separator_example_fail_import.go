@@ -4,7 +4,7 @@ import "fmt"
 
 import "strings"
 
-func ThisFunctionWillFailBecauseNoSeparator() {
+func ThisFunctionWillFailBecauseNoSeparator() { // want "Missing Separator after imports"
 	fmt.Println("Hello world")
 	strings.HasPrefix("He	", "llo")
 }
@@ -2,7 +2,7 @@ package example
 
 import "fmt"
 
-////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed before imports"
 
 import "os"
 
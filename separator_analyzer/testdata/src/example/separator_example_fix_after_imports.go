@@ -0,0 +1,10 @@
+package example
+
+import "fmt"
+
+
+//////////////////////////////////////////////////////////////////////////////// // want "Each Separator should be surrounded by exactly one empty line"
+
+func FixSeparatorAfterImports() { // want "Missing Separator after imports"
+	fmt.Println("separator after imports")
+}
@@ -0,0 +1,9 @@
+package example
+
+//////////////////////////////////////////////////////////////////////////////// // want "Each Separator should be surrounded by exactly one empty line"
+func FixEmptyLinesAfter() {}
+
+
+//////////////////////////////////////////////////////////////////////////////// // want "Each Separator should be surrounded by exactly one empty line"
+
+func FixEmptyLinesBefore() {}
@@ -0,0 +1,7 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+func FixSeparatorAtTheEnd() {}
+
+//////////////////////////////////////////////////////////////////////////////// // want "Separators at the end of the file are not allowed"
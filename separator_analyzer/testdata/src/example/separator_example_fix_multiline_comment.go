@@ -0,0 +1,11 @@
+package example
+
+//////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed a part of multiline comment" "Each Separator should be surrounded by exactly one empty line"
+// FixMultilineComment has a separator glued to its doc comment.
+func FixMultilineComment() {} // want +2 "Separator is not allowed a part of multiline comment" "Each Separator should be surrounded by exactly one empty line"
+
+/*
+////////////////////////////////////////////////////////////////////////////////
+*/
+
+func FixMultilineBlockComment() {}
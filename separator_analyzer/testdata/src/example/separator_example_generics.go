@@ -0,0 +1,69 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+func NewSet[T comparable]() *Set[T] {
+	return &Set[T]{items: make(map[T]struct{})}
+}
+
+func (s *Set[T]) Add(item T) {
+	s.items[item] = struct{}{}
+}
+
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s.items[item]
+	return ok
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Pair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+func MakePair[K any, V any](key K, value V) Pair[K, V] {
+	pair := &Pair[K, V]{Key: key, Value: value}
+	return *pair
+}
+
+func NewPairPointer[K any, V any](key K, value V) *Pair[K, V] {
+	pair := &Pair[K, V]{Key: key, Value: value}
+	return pair
+}
+
+func (p *Pair[K, V]) Swap() *Pair[V, K] {
+	return &Pair[V, K]{Key: p.Value, Value: p.Key}
+}
+
+func (p Pair[K, V]) String() string {
+	return "pair"
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+func (s *Set[T]) Len() int { // want `Method with receiver 'Set' is not allowed in the same group as struct 'Stack'`
+	return len(s.items)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Queue[T any] struct {
+	items []T
+}
+
+func QueueLen[T any](q *Queue[T]) int { // want `Function which is not a constructor for struct 'QueueLen' is not allowed in the same group as struct 'Queue'`
+	return len(q.items)
+}
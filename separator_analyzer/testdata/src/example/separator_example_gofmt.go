@@ -0,0 +1,15 @@
+package example
+
+import "fmt" // want +2 "Separator is mangled by gofmt"
+
+// //////////////////////////////////////////////////////////////////////////////
+
+type GofmtExample struct{}
+
+func (g *GofmtExample) Print() {
+	fmt.Println("gofmt")
+} // want +2 "Separator is mangled by gofmt"
+
+// //////////////////////////////////////////////////////////////////////////////
+
+func gofmtHelper() {}
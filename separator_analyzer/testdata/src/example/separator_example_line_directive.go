@@ -0,0 +1,14 @@
+package example
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+
+//line gen.tmpl:5000
+func generatedFirst() {
+	fmt.Println("first")
+}
+//////////////////////////////////////////////////////////////////////////////// // want "Each Separator should be surrounded by exactly one empty line"
+func generatedSecond() {
+	fmt.Println("second")
+}
@@ -0,0 +1,33 @@
+package example
+
+import "fmt" // want +2 "Malformed separator"
+
+///////////////////////////////////////////////////////////////////////////////
+
+func malformedZero() {
+	fmt.Println("zero")
+} // want +2 "Malformed separator"
+
+///////////////////////////////////////////////////////////////////////////////
+
+func malformedFirst() {
+	fmt.Println("first")
+} // want +2 "Malformed separator"
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func malformedSecond() {
+	fmt.Println("second")
+} // want +2 "Separator is mangled by gofmt"
+
+// //////////////////////////////////////////////////////////////////////////////
+
+func malformedThird() {
+	fmt.Println("third")
+} // want +2 "Malformed separator"
+
+//////////////////////////////////////////////////////////////////////////////// Helpers
+
+func malformedFourth() {
+	fmt.Println("fourth")
+}
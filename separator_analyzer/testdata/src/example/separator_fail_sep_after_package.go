@@ -1,6 +1,6 @@
 package example
 
-////////////////////////////////////////////////////////////////////////////////
+//////////////////////////////////////////////////////////////////////////////// // want "Separator is not allowed before imports"
 
 import "fmt"
 
@@ -0,0 +1,13 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Greet(name string) {
+	fmt.Println("hello,", name)
+} // want +2 "Separators at the end of the file are not allowed"
+
+////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,13 @@
+package example // want +2 "Separator is not allowed before imports"
+
+////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Greet(name string) {
+	fmt.Println("hello,", name)
+}
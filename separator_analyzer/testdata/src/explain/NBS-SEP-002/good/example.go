@@ -0,0 +1,11 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Greet(name string) {
+	fmt.Println("hello,", name)
+}
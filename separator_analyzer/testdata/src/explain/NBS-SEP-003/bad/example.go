@@ -0,0 +1,5 @@
+package example // want +2 "Separator is not allowed a part of multiline comment"
+
+////////////////////////////////////////////////////////////////////////////////
+// Greeting is the word used to greet.
+const Greeting = "hello"
@@ -0,0 +1,6 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Greeting is the word used to greet.
+const Greeting = "hello"
@@ -0,0 +1,15 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Process(items []string) []string {
+	result := make([]string, 0, len(items)) // want +2 "Separator is not allowed over code"
+
+	////////////////////////////////////////////////////////////////////////////////
+
+	for _, item := range items {
+		result = append(result, item)
+	}
+
+	return result
+}
@@ -0,0 +1,12 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Process(items []string) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item)
+	}
+
+	return result
+}
@@ -0,0 +1,14 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Greeter struct {
+	Name string
+} // want +3 "Each Separator should be surrounded by exactly one empty line"
+
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Counter struct {
+	Count int
+}
@@ -0,0 +1,15 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Greeter struct {
+	Name string
+} // want +2 "Empty section detected"
+
+////////////////////////////////////////////////////////////////////////////////
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Counter struct {
+	Count int
+}
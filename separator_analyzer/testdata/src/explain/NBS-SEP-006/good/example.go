@@ -0,0 +1,13 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Greeter struct {
+	Name string
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Counter struct {
+	Count int
+}
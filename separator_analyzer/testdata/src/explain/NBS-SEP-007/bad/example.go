@@ -0,0 +1,5 @@
+package example // want "Missing Separator after package declaration when no imports present"
+
+func Add(a int, b int) int {
+	return a + b
+}
@@ -0,0 +1,9 @@
+package example
+
+import (
+	"fmt"
+)
+
+func Greet(name string) { // want "Missing Separator after imports"
+	fmt.Println("hello,", name)
+}
@@ -0,0 +1,11 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Greeter struct { // want "Only one interface or struct declaration is allowed between separators"
+	Name string
+}
+
+type Counter struct {
+	Count int
+}
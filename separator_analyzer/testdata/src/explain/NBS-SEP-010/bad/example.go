@@ -0,0 +1,13 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Greeter struct {
+	Name string
+} // want +2 "Malformed separator"
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Counter struct {
+	Count int
+}
@@ -0,0 +1,7 @@
+package rules // want "warning: Missing Separator after package declaration when no imports present"
+
+func RulesExample() int {
+	return 42
+}
+
+////////////////////////////////////////////////////////////////////////////////
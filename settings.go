@@ -0,0 +1,247 @@
+package nbs_go_lint
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jkuradobery/nbs-go-lint/imports_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/line_breaks_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/method_file_analyzer"
+	signature "github.com/jkuradobery/nbs-go-lint/multiline_signature_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/separator_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/single_line_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/suppress"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Settings is the plugin configuration from the "settings" section of the
+// custom linter in .golangci.yml, e.g.:
+//
+//	linters-settings:
+//	  custom:
+//	    nbs-go-lint:
+//	      type: module
+//	      settings:
+//	        multiline-signature:
+//	          enabled: false
+type Settings struct {
+	Separator          separator_analyzer.Settings   `json:"separator"`
+	LineBreaks         line_breaks_analyzer.Settings `json:"line-breaks"`
+	MultilineSignature signature.Settings            `json:"multiline-signature"`
+	SingleLine         single_line_analyzer.Settings `json:"single-line"`
+	Imports            imports_analyzer.Settings     `json:"imports"`
+	MethodFile         method_file_analyzer.Settings `json:"method-file"`
+}
+
+// SettingsFilename is the settings file of the standalone commands, it has
+// the format of the plugin settings:
+//
+//	single-line:
+//	  enabled: true
+const SettingsFilename = ".nbs-go-lint.yml"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func DecodeSettings(conf any) (Settings, error) {
+	settings, err := register.DecodeSettings[Settings](conf)
+	if err != nil {
+		return Settings{}, fmt.Errorf("nbs-go-lint: invalid settings: %w", err)
+	}
+
+	if err := settings.Separator.Validate(); err != nil {
+		return Settings{}, fmt.Errorf("nbs-go-lint: invalid separator settings: %w", err)
+	}
+
+	if err := settings.SingleLine.Validate(); err != nil {
+		return Settings{}, fmt.Errorf("nbs-go-lint: invalid single-line settings: %w", err)
+	}
+
+	if err := settings.MethodFile.Validate(); err != nil {
+		return Settings{}, fmt.Errorf("nbs-go-lint: invalid method-file settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// FindSettings decodes the settings file in the directory or in the nearest
+// parent directory, the default settings are returned without the file.
+func FindSettings(dir string) (Settings, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	for {
+		filename := filepath.Join(dir, SettingsFilename)
+		data, err := os.ReadFile(filename)
+		if err == nil {
+			var conf any
+			if err := yaml.Unmarshal(data, &conf); err != nil {
+				return Settings{}, fmt.Errorf("nbs-go-lint: %s: %w", filename, err)
+			}
+
+			return DecodeSettings(conf)
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return Settings{}, err
+		}
+
+		if filepath.Dir(dir) == dir {
+			return Settings{}, nil
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Analyzers(settings Settings) []*analysis.Analyzer {
+	analyzers := make([]*analysis.Analyzer, 0, 7)
+	// skipped are the names and categories of disabled analyzers, directives
+	// naming them are not reported as unused.
+	var skipped []string
+	if settings.LineBreaks.IsEnabled() {
+		analyzers = append(
+			analyzers,
+			line_breaks_analyzer.LineBreakAfterRbracket(
+				rules.WithSettings(settings.LineBreaks),
+			),
+		)
+	} else {
+		skipped = append(
+			skipped,
+			"LineBreakAfterRbracket",
+			line_breaks_analyzer.Category,
+		)
+	}
+
+	if settings.Separator.IsEnabled() {
+		analyzers = append(
+			analyzers,
+			separator_analyzer.SeparatorAnalyzer(
+				rules.WithSettings(settings.Separator),
+			),
+		)
+	} else {
+		skipped = append(
+			skipped,
+			"SeparatorAnalyzer",
+			separator_analyzer.Category,
+		)
+	}
+
+	if settings.MultilineSignature.IsEnabled() {
+		analyzers = append(
+			analyzers,
+			signature.LineBreakAfterMultilineFunctionSignatureAnalyzer(
+				rules.WithSettings(settings.MultilineSignature),
+			),
+		)
+	} else {
+		skipped = append(
+			skipped,
+			"LineBreakAfterMultilineFunctionSignatureAnalyzer",
+			signature.Category,
+		)
+	}
+
+	if settings.SingleLine.IsEnabled() {
+		analyzers = append(
+			analyzers,
+			single_line_analyzer.SingleLineExpressionAnalyzer(
+				rules.WithSettings(settings.SingleLine),
+			),
+		)
+	} else {
+		skipped = append(
+			skipped,
+			"SingleLineExpressionAnalyzer",
+			single_line_analyzer.Category,
+		)
+	}
+
+	if settings.Imports.IsEnabled() {
+		analyzers = append(
+			analyzers,
+			imports_analyzer.ImportGroupsAnalyzer(
+				rules.WithSettings(settings.Imports),
+			),
+		)
+	} else {
+		skipped = append(
+			skipped,
+			"ImportGroupsAnalyzer",
+			imports_analyzer.Category,
+		)
+	}
+
+	if settings.MethodFile.IsEnabled() {
+		analyzers = append(
+			analyzers,
+			method_file_analyzer.MethodFileAnalyzer(
+				rules.WithSettings(settings.MethodFile),
+			),
+		)
+	} else {
+		skipped = append(
+			skipped,
+			"MethodFileAnalyzer",
+			method_file_analyzer.Category,
+		)
+	}
+
+	if len(analyzers) == 0 {
+		return analyzers
+	}
+
+	for i, analyzer := range analyzers {
+		analyzers[i] = suppress.Wrap(analyzer)
+	}
+
+	return append(
+		analyzers,
+		suppress.UnusedSuppressionAnalyzer(skipped, analyzers...),
+	)
+}
+
+// WithOptInAnalyzers enables the analyzers which are disabled by default,
+// e.g. to document all rules.
+func (s Settings) WithOptInAnalyzers() Settings {
+	enabled := true
+	s.SingleLine.Enabled = &enabled
+	return s
+}
+
+// Rules returns the rules of the analyzer returned by Analyzers, so drivers
+// can describe the checks behind the diagnostics.
+func Rules(analyzer *analysis.Analyzer) []rules.Rule {
+	switch analyzer.Name {
+	case "LineBreakAfterRbracket":
+		return line_breaks_analyzer.Rules()
+	case "SeparatorAnalyzer":
+		return separator_analyzer.Rules()
+	case "LineBreakAfterMultilineFunctionSignatureAnalyzer":
+		return signature.Rules()
+	case "SingleLineExpressionAnalyzer":
+		return single_line_analyzer.Rules()
+	case "ImportGroupsAnalyzer":
+		return imports_analyzer.Rules()
+	case "MethodFileAnalyzer":
+		return method_file_analyzer.Rules()
+	case "UnusedSuppressionAnalyzer":
+		return suppress.Rules()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,211 @@
+package nbs_go_lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golangci/plugin-module-register/register"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/testcommon"
+)
+
+func TestDecodeSettings(t *testing.T) {
+	settings, err := DecodeSettings(nil)
+	require.NoError(t, err)
+	require.Len(t, Analyzers(settings), 6)
+
+	settings, err = DecodeSettings(map[string]any{
+		"separator": map[string]any{
+			"enabled": false,
+		},
+		"multiline-signature": map[string]any{
+			"enabled": true,
+		},
+		"single-line": map[string]any{
+			"enabled": false,
+		},
+		"imports": map[string]any{
+			"enabled": false,
+		},
+		"method-file": map[string]any{
+			"enabled": false,
+		},
+	})
+	require.NoError(t, err)
+
+	analyzers := Analyzers(settings)
+	require.Len(t, analyzers, 3)
+	require.Equal(t, "LineBreakAfterRbracket", analyzers[0].Name)
+	require.Equal(
+		t,
+		"LineBreakAfterMultilineFunctionSignatureAnalyzer",
+		analyzers[1].Name,
+	)
+	require.Equal(t, "UnusedSuppressionAnalyzer", analyzers[2].Name)
+	require.Equal(t, analyzers[:2], analyzers[2].Requires)
+}
+
+func TestAnalyzersSkipSuppressionsOfDisabledAnalyzers(t *testing.T) {
+	analyzers := Analyzers(Settings{})
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		analyzers[len(analyzers)-1],
+		"suppressions/",
+	)
+}
+
+func TestDecodeSettingsUnknownKey(t *testing.T) {
+	_, err := DecodeSettings(map[string]any{
+		"separators": map[string]any{},
+	})
+	require.ErrorContains(t, err, `unknown field "separators"`)
+
+	_, err = NewNbsAnalyzerPlugin(map[string]any{
+		"line-breaks": map[string]any{
+			"enable": true,
+		},
+	})
+	require.ErrorContains(t, err, `unknown field "enable"`)
+}
+
+func TestDecodeSettingsSeparatorRules(t *testing.T) {
+	_, err := DecodeSettings(map[string]any{
+		"separator": map[string]any{
+			"rules": map[string]any{
+				"all":                  "off",
+				"separator-at-the-end": "warning",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = DecodeSettings(map[string]any{
+		"separator": map[string]any{
+			"rules": map[string]any{
+				"separator-at-the-beginning": "off",
+			},
+		},
+	})
+	require.ErrorContains(t, err, `unknown rule "separator-at-the-beginning"`)
+
+	_, err = DecodeSettings(map[string]any{
+		"separator": map[string]any{
+			"rules": map[string]any{
+				"empty-section": "info",
+			},
+		},
+	})
+	require.ErrorContains(t, err, `unknown severity "info"`)
+}
+
+func TestDecodeSettingsSingleLine(t *testing.T) {
+	settings, err := DecodeSettings(map[string]any{
+		"single-line": map[string]any{
+			"max-line-length": 120,
+			"tab-width":       8,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, Analyzers(settings), 6)
+
+	settings, err = DecodeSettings(map[string]any{
+		"single-line": map[string]any{
+			"enabled": true,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, Analyzers(settings), 7)
+
+	_, err = DecodeSettings(map[string]any{
+		"single-line": map[string]any{
+			"tab-width": -1,
+		},
+	})
+	require.ErrorContains(t, err, "tab-width should not be negative")
+}
+
+func TestDecodeSettingsMethodFile(t *testing.T) {
+	_, err := DecodeSettings(map[string]any{
+		"method-file": map[string]any{
+			"allowed-files": []any{"*_test.go", "zz_*.go"},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = DecodeSettings(map[string]any{
+		"method-file": map[string]any{
+			"allowed-files": []any{"[a-"},
+		},
+	})
+	require.ErrorContains(t, err, "invalid allowed-files pattern")
+}
+
+func TestFindSettings(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "pkg", "sub")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	settings, err := FindSettings(dir)
+	require.NoError(t, err)
+	require.Len(t, Analyzers(settings), 6)
+
+	filename := filepath.Join(root, SettingsFilename)
+	data := "single-line:\n  enabled: true\n  max-line-length: 100\n"
+	require.NoError(t, os.WriteFile(filename, []byte(data), 0o644))
+
+	settings, err = FindSettings(dir)
+	require.NoError(t, err)
+	require.Len(t, Analyzers(settings), 7)
+	require.Equal(t, 100, settings.SingleLine.MaxLineLength)
+
+	data = "single-line:\n  unknown: true\n"
+	require.NoError(t, os.WriteFile(filename, []byte(data), 0o644))
+
+	_, err = FindSettings(dir)
+	require.ErrorContains(t, err, "invalid settings")
+
+	require.NoError(t, os.WriteFile(filename, []byte("single-line: ["), 0o644))
+
+	_, err = FindSettings(dir)
+	require.ErrorContains(t, err, SettingsFilename)
+}
+
+func TestLoadMode(t *testing.T) {
+	plugin, err := NewNbsAnalyzerPlugin(nil)
+	require.NoError(t, err)
+	require.Equal(t, register.LoadModeSyntax, plugin.GetLoadMode())
+
+	plugin, err = NewNbsAnalyzerPlugin(map[string]any{
+		"separator": map[string]any{
+			"type-checked-constructors": true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, register.LoadModeTypesInfo, plugin.GetLoadMode())
+}
+
+func TestRules(t *testing.T) {
+	ids := make(map[string]struct{})
+	codes := make(map[string]struct{})
+	for _, analyzer := range Analyzers(Settings{}.WithOptInAnalyzers()) {
+		analyzerRules := Rules(analyzer)
+		require.NotEmpty(t, analyzerRules, analyzer.Name)
+		require.Equal(t, rules.DocumentationURL, analyzer.URL, analyzer.Name)
+
+		for _, rule := range analyzerRules {
+			require.NotContains(t, ids, rule.ID)
+			require.NotContains(t, codes, rule.Code)
+			require.Regexp(t, `^NBS-[A-Z]{3}-\d{3}$`, rule.Code)
+			require.NotEmpty(t, rule.Doc, rule.Code)
+			require.NotEmpty(t, rule.Rationale, rule.Code)
+			require.NotNil(t, rule.Examples, rule.Code)
+			ids[rule.ID] = struct{}{}
+			codes[rule.Code] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,467 @@
+package single_line_analyzer
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	DefaultMaxLineLength = 80
+	DefaultTabWidth      = 4
+)
+
+const (
+	RuleSingleLineExpression = "single-line-expression"
+	RuleSingleLineSignature  = "single-line-signature"
+)
+
+const (
+	CodeSingleLineExpression = "NBS-SLN-001"
+	CodeSingleLineSignature  = "NBS-SLN-002"
+)
+
+const Category = "single_line"
+
+const (
+	ExpressionFitsOnOneLine = "Expression fits on one line and should be on one line."
+	SignatureFitsOnOneLine  = "Function signature fits on one line and should be on one line."
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed testdata/src/explain
+var examples embed.FS
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Rules() []rules.Rule {
+	return rules.WithExamples(examples, []rules.Rule{
+		{
+			ID:   RuleSingleLineExpression,
+			Code: CodeSingleLineExpression,
+			Doc:  "Calls, composite literals and binary expressions which fit on one line are on one line.",
+			Rationale: "Splitting a short expression over several lines makes the " +
+				"function longer without making the expression easier to read.",
+		},
+		{
+			ID:   RuleSingleLineSignature,
+			Code: CodeSingleLineSignature,
+			Doc:  "Function signatures which fit on one line are on one line.",
+			Rationale: "A signature on one line can be found with grep and read at a " +
+				"glance, multiline signatures are kept for long parameter lists.",
+		},
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Settings configure SingleLineExpressionAnalyzer, they are decoded from the
+// golangci-lint plugin configuration. Zero values mean defaults.
+//
+// The analyzer is disabled unless Enabled is set, as existing code often
+// splits expressions which fit on one line.
+type Settings struct {
+	rules.OptIn
+
+	MaxLineLength int `json:"max-line-length,omitempty"`
+	TabWidth      int `json:"tab-width,omitempty"`
+}
+
+func (s Settings) Validate() error {
+	if s.MaxLineLength < 0 {
+		return errors.New("max-line-length should not be negative")
+	}
+
+	if s.TabWidth < 0 {
+		return errors.New("tab-width should not be negative")
+	}
+
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func SingleLineExpressionAnalyzer(
+	options ...rules.Option[Settings],
+) *analysis.Analyzer {
+
+	settings := rules.ApplyOptions(options...)
+
+	if settings.MaxLineLength == 0 {
+		settings.MaxLineLength = DefaultMaxLineLength
+	}
+
+	if settings.TabWidth == 0 {
+		settings.TabWidth = DefaultTabWidth
+	}
+
+	analyzer := &analysis.Analyzer{
+		Name: "SingleLineExpressionAnalyzer",
+		Doc:  "Checks that expressions which fit on one line are on one line.",
+		URL:  rules.DocumentationURL,
+		Run: func(pass *analysis.Pass) (any, error) {
+			if err := settings.Validate(); err != nil {
+				return nil, err
+			}
+
+			for _, file := range pass.Files {
+				newSingleLineAnalysis(pass, file, settings).run()
+			}
+
+			return nil, nil
+		},
+	}
+	analyzer.Flags.IntVar(
+		&settings.MaxLineLength,
+		"max-line-length",
+		settings.MaxLineLength,
+		"maximum length of a line with joined expression",
+	)
+	analyzer.Flags.IntVar(
+		&settings.TabWidth,
+		"tab-width",
+		settings.TabWidth,
+		"width of a tab used to compute the line length",
+	)
+
+	return analyzer
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type singleLineAnalysis struct {
+	pass     *analysis.Pass
+	file     *ast.File
+	settings Settings
+	lines    []string
+	// operands of binary expressions are joined only together with the
+	// whole expression.
+	operands map[ast.Expr]struct{}
+	// headers maps conditions of if, for and switch statements to their
+	// statements, so the empty line after a joined header is removed.
+	headers map[ast.Expr]header
+}
+
+// header is the part of an if, for or switch statement before the block.
+type header struct {
+	start  token.Pos
+	lbrace token.Pos
+}
+
+func newSingleLineAnalysis(
+	pass *analysis.Pass,
+	file *ast.File,
+	settings Settings,
+) *singleLineAnalysis {
+
+	filename := pass.Fset.PositionFor(file.Pos(), false).Filename
+	data, err := pass.ReadFile(filename)
+	if err != nil {
+		log.Fatalf("Error reading file %v: %v", filename, err)
+	}
+
+	return &singleLineAnalysis{
+		pass:     pass,
+		file:     file,
+		settings: settings,
+		lines:    strings.Split(string(data), "\n"),
+		operands: make(map[ast.Expr]struct{}),
+		headers:  make(map[ast.Expr]header),
+	}
+}
+
+func (s *singleLineAnalysis) run() {
+	ast.Inspect(s.file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncDecl:
+			s.checkSignature(node)
+		case *ast.IfStmt:
+			s.addHeader(node.Cond, node, node.Body)
+		case *ast.ForStmt:
+			s.addHeader(node.Cond, node, node.Body)
+		case *ast.SwitchStmt:
+			s.addHeader(node.Tag, node, node.Body)
+		case *ast.CallExpr, *ast.CompositeLit:
+			return !s.checkExpression(node.(ast.Expr))
+		case *ast.BinaryExpr:
+			_, isOperand := s.operands[node]
+			s.operands[node.X] = struct{}{}
+			s.operands[node.Y] = struct{}{}
+			return isOperand || !s.checkExpression(node)
+		}
+
+		return true
+	})
+}
+
+// addHeader records the condition of the statement, it is nil for loops
+// and switches without one.
+func (s *singleLineAnalysis) addHeader(
+	condition ast.Expr,
+	statement ast.Stmt,
+	body *ast.BlockStmt,
+) {
+
+	if condition != nil {
+		s.headers[condition] = header{
+			start:  statement.Pos(),
+			lbrace: body.Lbrace,
+		}
+	}
+}
+
+// checkExpression reports whether the expression is reported, nested
+// expressions of the reported one are joined with it.
+func (s *singleLineAnalysis) checkExpression(expression ast.Expr) bool {
+	if !s.isJoinable(expression.Pos(), expression.End(), expression) {
+		return false
+	}
+
+	joined, ok := joinExpression(s.text(expression.Pos(), expression.End()))
+	if !ok || !s.fits(expression.Pos(), expression.End(), joined) {
+		return false
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     expression.Pos(),
+		End:     expression.End(),
+		NewText: []byte(joined),
+	}}
+	header, ok := s.headers[expression]
+	if ok &&
+		s.line(header.start) == s.line(expression.Pos()) &&
+		s.line(header.lbrace) == s.line(expression.End()) {
+
+		// The whole header is joined, line break after the brace is only
+		// required for multiline headers.
+		edits = append(edits, s.removeEmptyLinesAfter(header.lbrace)...)
+	}
+
+	s.pass.Report(analysis.Diagnostic{
+		Pos:      expression.Pos(),
+		End:      expression.End(),
+		Category: Category,
+		URL:      rules.URL(CodeSingleLineExpression),
+		Message:  ExpressionFitsOnOneLine,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Join lines",
+			TextEdits: edits,
+		}},
+	})
+
+	return true
+}
+
+// checkSignature checks the part of the declaration from the func keyword
+// to the results, the receiver and the name are in between.
+func (s *singleLineAnalysis) checkSignature(function *ast.FuncDecl) {
+	start := function.Type.Pos()
+	end := function.Type.End()
+	if !s.isJoinable(start, end, function.Type) {
+		return
+	}
+
+	joined, ok := joinSignature(s.text(start, end))
+	if !ok || !s.fits(start, end, joined) {
+		return
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     start,
+		End:     end,
+		NewText: []byte(joined),
+	}}
+	if function.Body != nil {
+		// Line break after the signature is only required for
+		// multiline signatures.
+		edits = append(edits, s.removeEmptyLinesAfter(function.Body.Lbrace)...)
+	}
+
+	s.pass.Report(analysis.Diagnostic{
+		Pos:      start,
+		End:      end,
+		Category: Category,
+		URL:      rules.URL(CodeSingleLineSignature),
+		Message:  SignatureFitsOnOneLine,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Join lines",
+			TextEdits: edits,
+		}},
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// isJoinable reports whether the node spans several lines and joining them
+// does not lose comments or change the meaning of statements and strings.
+func (s *singleLineAnalysis) isJoinable(
+	start token.Pos,
+	end token.Pos,
+	node ast.Node,
+) bool {
+
+	if s.line(start) == s.line(end) {
+		return false
+	}
+
+	for _, group := range s.file.Comments {
+		if group.Pos() < end && start < group.End() {
+			return false
+		}
+	}
+
+	joinable := true
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.BlockStmt, *ast.StructType, *ast.InterfaceType:
+			joinable = joinable && s.line(node.Pos()) == s.line(node.End())
+		case *ast.BasicLit:
+			joinable = joinable && !strings.Contains(node.Value, "\n")
+		}
+
+		return joinable
+	})
+
+	return joinable
+}
+
+// fits reports whether the line with the node replaced by the joined text
+// is not longer than the limit.
+func (s *singleLineAnalysis) fits(
+	start token.Pos,
+	end token.Pos,
+	joined string,
+) bool {
+
+	startPosition := s.pass.Fset.PositionFor(start, false)
+	endPosition := s.pass.Fset.PositionFor(end, false)
+	before := s.lines[startPosition.Line-1][:startPosition.Column-1]
+	after := s.lines[endPosition.Line-1][endPosition.Column-1:]
+
+	length := utf8.RuneCountInString(before)
+	length += strings.Count(before, "\t") * (s.settings.TabWidth - 1)
+	length += utf8.RuneCountInString(joined)
+	length += utf8.RuneCountInString(strings.TrimRight(after, " \t\r"))
+	return length <= s.settings.MaxLineLength
+}
+
+func (s *singleLineAnalysis) removeEmptyLinesAfter(
+	lbrace token.Pos,
+) []analysis.TextEdit {
+
+	tokenFile := s.pass.Fset.File(lbrace)
+	line := s.line(lbrace)
+	next := line + 1
+	for next <= len(s.lines) && strings.TrimSpace(s.lines[next-1]) == "" {
+		next++
+	}
+
+	if next == line+1 || next > tokenFile.LineCount() {
+		return nil
+	}
+
+	return []analysis.TextEdit{{
+		Pos: tokenFile.LineStart(line + 1),
+		End: tokenFile.LineStart(next),
+	}}
+}
+
+func (s *singleLineAnalysis) line(pos token.Pos) int {
+	return s.pass.Fset.PositionFor(pos, false).Line
+}
+
+func (s *singleLineAnalysis) text(start token.Pos, end token.Pos) string {
+	tokenFile := s.pass.Fset.File(start)
+	startPosition := tokenFile.PositionFor(start, false)
+	endPosition := tokenFile.PositionFor(end, false)
+
+	lines := make([]string, 0, endPosition.Line-startPosition.Line+1)
+	for line := startPosition.Line; line <= endPosition.Line; line++ {
+		text := s.lines[line-1]
+		if line == endPosition.Line {
+			text = text[:endPosition.Column-1]
+		}
+
+		if line == startPosition.Line {
+			text = text[startPosition.Column-1:]
+		}
+
+		lines = append(lines, text)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// joinLines puts the text on one line, trailing commas before closing
+// brackets are removed.
+func joinLines(text string) string {
+	joined := ""
+	for index, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case index == 0 || line == "":
+		case strings.ContainsAny(line[:1], ")]}"):
+			joined = strings.TrimSuffix(joined, ",")
+		case !strings.ContainsAny(joined[len(joined)-1:], "([{"):
+			joined += " "
+		}
+
+		joined += line
+	}
+
+	return joined
+}
+
+// joinExpression joins the lines of the expression and prints it as gofmt
+// does, false is returned if the result is not a valid expression.
+func joinExpression(text string) (string, bool) {
+	fset := token.NewFileSet()
+	expression, err := parser.ParseExprFrom(fset, "", joinLines(text), 0)
+	if err != nil {
+		return "", false
+	}
+
+	return formatNode(fset, expression)
+}
+
+// joinSignature does the same as joinExpression for a function signature.
+func joinSignature(text string) (string, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(
+		fset,
+		"",
+		"package p\n\n"+joinLines(text)+"\n",
+		parser.SkipObjectResolution,
+	)
+	if err != nil || len(file.Decls) != 1 {
+		return "", false
+	}
+
+	return formatNode(fset, file.Decls[0])
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) (string, bool) {
+	var buffer bytes.Buffer
+	if err := format.Node(&buffer, fset, node); err != nil {
+		return "", false
+	}
+
+	text := buffer.String()
+	return text, !strings.Contains(text, "\n")
+}
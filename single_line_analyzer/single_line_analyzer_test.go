@@ -0,0 +1,40 @@
+package single_line_analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+	"github.com/jkuradobery/nbs-go-lint/testcommon"
+)
+
+func TestSingleLineExpressionAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(
+		t,
+		testcommon.TestdataDir(t),
+		SingleLineExpressionAnalyzer(),
+		"example/",
+	)
+}
+
+func TestSingleLineExpressionAnalyzerSettings(t *testing.T) {
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		SingleLineExpressionAnalyzer(rules.WithSettings(Settings{
+			MaxLineLength: 100,
+			TabWidth:      8,
+		})),
+		"limit/",
+	)
+}
+
+func TestSingleLineExpressionAnalyzerExamples(t *testing.T) {
+	testcommon.RunExamples(
+		t,
+		testcommon.TestdataDir(t),
+		SingleLineExpressionAnalyzer(),
+		Rules(),
+	)
+}
@@ -0,0 +1,86 @@
+package example
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Example struct {
+	a int
+	b string
+}
+
+// want +1 "Function signature fits on one line and should be on one line."
+func NewExample(
+	a int,
+	b string,
+) *Example {
+
+	// want +1 "Expression fits on one line and should be on one line."
+	return &Example{
+		a: a,
+		b: b,
+	}
+}
+
+func (e *Example) PrintWithAVeryLongNameWhichDoesNotFitOnOneLine(
+	prefix string,
+	suffix string,
+) {
+
+	// want +1 "Expression fits on one line and should be on one line."
+	fmt.Println(
+		prefix,
+		e.a,
+		suffix,
+	)
+	fmt.Println(
+		"this line is too long to be joined with the call of fmt.Println",
+		e.b,
+	)
+	fmt.Println(
+		"arguments with a comment", // comment
+		e.b,
+	)
+	fmt.Println("function literals are not joined", func() int {
+		return e.a
+	}())
+	fmt.Println(`raw
+string`)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func conditions(a int, b int, c int) bool {
+	// want +1 "Expression fits on one line and should be on one line."
+	if a > 0 &&
+		b > 0 &&
+		c > 0 {
+
+		return true
+	}
+
+	return a+b+c > 1000000000000 &&
+		a+b > 1000000000000000000 &&
+		fmt.Sprint(a) != fmt.Sprint(b)
+}
+
+func loop(values []int) {
+	// want +1 "Expression fits on one line and should be on one line."
+	for len(values) > 0 &&
+		values[0] > 0 {
+
+		values = values[1:]
+	}
+}
+
+func nested() {
+	fmt.Println(
+		"the outer call does not fit on one line",
+		// want +1 "Expression fits on one line and should be on one line."
+		[]int{
+			1,
+			2,
+			3,
+		},
+	)
+}
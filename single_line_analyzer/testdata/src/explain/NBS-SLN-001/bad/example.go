@@ -0,0 +1,16 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func printTotal(name string, total int) {
+	// want +1 "Expression fits on one line and should be on one line."
+	fmt.Printf(
+		"%s: %d\n",
+		name,
+		total,
+	)
+}
@@ -0,0 +1,11 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func printTotal(name string, total int) {
+	fmt.Printf("%s: %d\n", name, total)
+}
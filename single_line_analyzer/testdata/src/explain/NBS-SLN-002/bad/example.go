@@ -0,0 +1,12 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+// want +1 "Function signature fits on one line and should be on one line."
+func sum(
+	a int,
+	b int,
+) int {
+
+	return a + b
+}
@@ -0,0 +1,7 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+func sum(a int, b int) int {
+	return a + b
+}
@@ -0,0 +1,19 @@
+package limit
+
+import "fmt"
+
+////////////////////////////////////////////////////////////////////////////////
+
+func limit() {
+	// want +1 "Expression fits on one line and should be on one line."
+	fmt.Println(
+		"this line fits into 100 columns, but it does not fit into 80",
+		"!",
+	)
+	if true {
+		fmt.Println(
+			"with tab width 8 this line is 101 columns long",
+			"93 with tab width 4.",
+		)
+	}
+}
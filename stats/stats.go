@@ -0,0 +1,134 @@
+// Package stats counts issues of the NBS analyzers by rule, package and
+// file, so the style debt of a code base can be tracked over time.
+//
+// An issue is autofixable if the analyzer suggested a fix for it, such
+// issues are fixed by "nbs-go-lint -fix" and nbsfmt.
+package stats
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Stats struct {
+	Issues      int     `json:"issues"`
+	Autofixable int     `json:"autofixable"`
+	Rules       []Count `json:"rules"`
+	Packages    []Count `json:"packages"`
+	Files       []Count `json:"files"`
+}
+
+// New counts the issues, rules are identified by their codes and files by
+// paths relative to the root directory if they are inside it.
+func New(root string, issues []driver.Issue) *Stats {
+	stats := &Stats{}
+	rules := newCounter()
+	packages := newCounter()
+	files := newCounter()
+	for _, issue := range issues {
+		autofixable := len(issue.Fixes) != 0
+		stats.Issues++
+		if autofixable {
+			stats.Autofixable++
+		}
+
+		rule := issue.Code
+		if rule == "" {
+			rule = issue.Rule()
+		}
+
+		rules.add(rule, autofixable)
+		packages.add(issue.Package, autofixable)
+		files.add(driver.RelativePath(root, issue.Start.Filename), autofixable)
+	}
+
+	stats.Rules = rules.sorted()
+	stats.Packages = packages.sorted()
+	stats.Files = files.sorted()
+	return stats
+}
+
+func (s *Stats) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// WriteTable writes the totals and a table per grouping, rows are sorted by
+// the number of issues in descending order.
+func (s *Stats) WriteTable(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "%d issues, %d autofixable\n", s.Issues, s.Autofixable)
+	writeCounts(writer, "RULE", s.Rules)
+	writeCounts(writer, "PACKAGE", s.Packages)
+	writeCounts(writer, "FILE", s.Files)
+	return writer.Flush()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Count is the number of issues of a rule, a package or a file.
+type Count struct {
+	Name        string `json:"name"`
+	Issues      int    `json:"issues"`
+	Autofixable int    `json:"autofixable"`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type counter struct {
+	counts map[string]*Count
+}
+
+func newCounter() *counter {
+	return &counter{counts: make(map[string]*Count)}
+}
+
+func (c *counter) add(name string, autofixable bool) {
+	count, ok := c.counts[name]
+	if !ok {
+		count = &Count{Name: name}
+		c.counts[name] = count
+	}
+
+	count.Issues++
+	if autofixable {
+		count.Autofixable++
+	}
+}
+
+func (c *counter) sorted() []Count {
+	counts := make([]Count, 0, len(c.counts))
+	for _, count := range c.counts {
+		counts = append(counts, *count)
+	}
+
+	slices.SortFunc(counts, func(a, b Count) int {
+		if a.Issues != b.Issues {
+			return b.Issues - a.Issues
+		}
+
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return counts
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// writeCounts separates the table by an empty line, so its columns are
+// aligned independently of other tables.
+func writeCounts(w io.Writer, title string, counts []Count) {
+	fmt.Fprintf(w, "\n%s\tISSUES\tAUTOFIXABLE\n", title)
+	for _, count := range counts {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", count.Name, count.Issues, count.Autofixable)
+	}
+}
@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jkuradobery/nbs-go-lint/driver"
+)
+
+func TestNew(t *testing.T) {
+	root := t.TempDir()
+	fix := []driver.Fix{{Message: "Add line break"}}
+	issues := []driver.Issue{
+		{
+			Analyzer: "LineBreakAfterRbracket",
+			Category: "line_breaks",
+			Package:  "example/a",
+			Code:     "NBS-LBR-002",
+			Start:    token.Position{Filename: filepath.Join(root, "a", "a.go"), Line: 10},
+			Fixes:    fix,
+		},
+		{
+			Analyzer: "LineBreakAfterRbracket",
+			Category: "line_breaks",
+			Package:  "example/a",
+			Code:     "NBS-LBR-002",
+			Start:    token.Position{Filename: filepath.Join(root, "a", "b.go"), Line: 20},
+			Fixes:    fix,
+		},
+		{
+			Analyzer: "SeparatorAnalyzer",
+			Category: "separator",
+			Package:  "example/b",
+			Code:     "NBS-SEP-009",
+			Start:    token.Position{Filename: filepath.Join(root, "b", "b.go"), Line: 3},
+		},
+		{
+			Analyzer: "custom",
+			Package:  "example/b",
+			Start:    token.Position{Filename: filepath.Join(root, "b", "b.go"), Line: 5},
+		},
+	}
+
+	stats := New(root, issues)
+	require.Equal(t, 4, stats.Issues)
+	require.Equal(t, 2, stats.Autofixable)
+	require.Equal(t, []Count{
+		{Name: "NBS-LBR-002", Issues: 2, Autofixable: 2},
+		{Name: "NBS-SEP-009", Issues: 1},
+		{Name: "custom", Issues: 1},
+	}, stats.Rules)
+	require.Equal(t, []Count{
+		{Name: "example/a", Issues: 2, Autofixable: 2},
+		{Name: "example/b", Issues: 2},
+	}, stats.Packages)
+	require.Equal(t, []Count{
+		{Name: "b/b.go", Issues: 2},
+		{Name: "a/a.go", Issues: 1, Autofixable: 1},
+		{Name: "a/b.go", Issues: 1, Autofixable: 1},
+	}, stats.Files)
+
+	var buffer bytes.Buffer
+	require.NoError(t, stats.WriteTable(&buffer))
+	require.Equal(t, `4 issues, 2 autofixable
+
+RULE         ISSUES  AUTOFIXABLE
+NBS-LBR-002  2       2
+NBS-SEP-009  1       0
+custom       1       0
+
+PACKAGE    ISSUES  AUTOFIXABLE
+example/a  2       2
+example/b  2       0
+
+FILE    ISSUES  AUTOFIXABLE
+b/b.go  2       0
+a/a.go  1       1
+a/b.go  1       1
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, stats.WriteJSON(&buffer))
+
+	var decoded Stats
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	require.Equal(t, *stats, decoded)
+}
+
+func TestNewWithoutIssues(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, New(".", nil).WriteJSON(&buffer))
+	require.JSONEq(t, `{
+		"issues": 0,
+		"autofixable": 0,
+		"rules": [],
+		"packages": [],
+		"files": []
+	}`, buffer.String())
+}
@@ -0,0 +1,345 @@
+// Package suppress implements //nbs:ignore directives which silence
+// diagnostics of the NBS analyzers:
+//
+//	//nbs:ignore separator,line_breaks the reason of the suppression
+//
+// The directive names analyzers or diagnostic categories and requires a
+// reason. Its scope depends on the position:
+//
+//   - before the package clause it covers the whole file;
+//   - in the doc comment of a top-level declaration or on the line right
+//     above it it covers the declaration;
+//   - after code it covers the line;
+//   - on its own line elsewhere it covers the next line.
+package suppress
+
+import (
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+const Directive = "//nbs:ignore"
+
+const (
+	UnusedSuppressionFormat = "Unused suppression of %s"
+	MissingReasonMessage    = "Suppression requires analyzers or categories and a reason: " +
+		Directive + " <names> <reason>"
+)
+
+const (
+	RuleSuppressionReason = "suppression-reason"
+	RuleUnusedSuppression = "unused-suppression"
+)
+
+const (
+	CodeSuppressionReason = "NBS-SUP-001"
+	CodeUnusedSuppression = "NBS-SUP-002"
+)
+
+const analyzerCategory = "suppression"
+
+////////////////////////////////////////////////////////////////////////////////
+
+//go:embed testdata/src/explain
+var examples embed.FS
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Rules() []rules.Rule {
+	return rules.WithExamples(examples, []rules.Rule{
+		{
+			ID:   RuleSuppressionReason,
+			Code: CodeSuppressionReason,
+			Doc:  "Suppression directives name analyzers or categories and give a reason.",
+			Rationale: "A suppression without a reason cannot be reviewed, and the " +
+				"next reader does not know whether it can be removed.",
+		},
+		{
+			ID:   RuleUnusedSuppression,
+			Code: CodeUnusedSuppression,
+			Doc:  "Suppression directives suppress diagnostics of every named analyzer or category.",
+			Rationale: "Suppressions outlive the code they were written for, unused " +
+				"ones would silently hide new issues in their scope.",
+		},
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// directive is a parsed //nbs:ignore comment with the lines it covers.
+type directive struct {
+	comment   *ast.Comment
+	filename  string
+	targets   []string
+	reason    string
+	startLine int
+	endLine   int
+}
+
+func (d *directive) isValid() bool {
+	return len(d.targets) != 0 && d.reason != ""
+}
+
+func (d *directive) covers(position token.Position) bool {
+	return position.Filename == d.filename &&
+		d.startLine <= position.Line &&
+		position.Line <= d.endLine
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// usage is the result of a wrapped analyzer: the targets of directives which
+// suppressed its diagnostics.
+type usage struct {
+	targets map[*ast.Comment]map[string]struct{}
+}
+
+func newUsage() *usage {
+	return &usage{targets: make(map[*ast.Comment]map[string]struct{})}
+}
+
+func (u *usage) add(comment *ast.Comment, target string) {
+	if _, ok := u.targets[comment]; !ok {
+		u.targets[comment] = make(map[string]struct{})
+	}
+
+	u.targets[comment][target] = struct{}{}
+}
+
+func (u *usage) isUsed(comment *ast.Comment, target string) bool {
+	_, ok := u.targets[comment][target]
+	return ok
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Wrap returns a copy of the analyzer which drops diagnostics suppressed by
+// directives naming the analyzer or the category of the diagnostic. The
+// analyzer should not have a result of its own, the result of the copy is
+// used by UnusedSuppressionAnalyzer.
+func Wrap(analyzer *analysis.Analyzer) *analysis.Analyzer {
+	wrapped := *analyzer
+	wrapped.ResultType = reflect.TypeOf((*usage)(nil))
+	wrapped.Run = func(pass *analysis.Pass) (any, error) {
+		directives, err := parseDirectives(pass)
+		if err != nil {
+			return nil, err
+		}
+
+		used := newUsage()
+		suppressingPass := *pass
+		suppressingPass.Report = func(diagnostic analysis.Diagnostic) {
+			position := pass.Fset.PositionFor(diagnostic.Pos, false)
+			for _, directive := range directives {
+				if !directive.isValid() || !directive.covers(position) {
+					continue
+				}
+
+				for _, target := range directive.targets {
+					if target == analyzer.Name || target == diagnostic.Category {
+						used.add(directive.comment, target)
+						return
+					}
+				}
+			}
+
+			pass.Report(diagnostic)
+		}
+
+		_, err = analyzer.Run(&suppressingPass)
+		return used, err
+	}
+
+	return &wrapped
+}
+
+// UnusedSuppressionAnalyzer reports directives without a reason and targets
+// of directives which suppressed nothing in the wrapped analyzers. Skipped
+// targets name analyzers which are not run, e.g. disabled ones, and their
+// categories, they are never reported as unused.
+func UnusedSuppressionAnalyzer(
+	skipped []string,
+	analyzers ...*analysis.Analyzer,
+) *analysis.Analyzer {
+
+	return &analysis.Analyzer{
+		Name:     "UnusedSuppressionAnalyzer",
+		Doc:      "Checks that " + Directive + " directives have a reason and suppress diagnostics.",
+		URL:      rules.DocumentationURL,
+		Requires: analyzers,
+		Run: func(pass *analysis.Pass) (any, error) {
+			directives, err := parseDirectives(pass)
+			if err != nil {
+				return nil, err
+			}
+
+			used := newUsage()
+			for _, analyzer := range analyzers {
+				for comment, targets := range pass.ResultOf[analyzer].(*usage).targets {
+					for target := range targets {
+						used.add(comment, target)
+					}
+				}
+			}
+
+			for _, directive := range directives {
+				reportUnused(pass, directive, used, skipped)
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func reportUnused(
+	pass *analysis.Pass,
+	directive *directive,
+	used *usage,
+	skipped []string,
+) {
+
+	if !directive.isValid() {
+		pass.Report(analysis.Diagnostic{
+			Pos:      directive.comment.Pos(),
+			End:      directive.comment.End(),
+			Category: analyzerCategory,
+			URL:      rules.URL(CodeSuppressionReason),
+			Message:  MissingReasonMessage,
+		})
+		return
+	}
+
+	for _, target := range directive.targets {
+		if used.isUsed(directive.comment, target) ||
+			slices.Contains(skipped, target) {
+
+			continue
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      directive.comment.Pos(),
+			End:      directive.comment.End(),
+			Category: analyzerCategory,
+			URL:      rules.URL(CodeUnusedSuppression),
+			Message:  fmt.Sprintf(UnusedSuppressionFormat, target),
+		})
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func parseDirectives(pass *analysis.Pass) ([]*directive, error) {
+	var directives []*directive
+	for _, file := range pass.Files {
+		filename := pass.Fset.PositionFor(file.Pos(), false).Filename
+		var lines []string
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				targets, reason, ok := parseDirective(comment.Text)
+				if !ok {
+					continue
+				}
+
+				if lines == nil {
+					data, err := pass.ReadFile(filename)
+					if err != nil {
+						return nil, err
+					}
+
+					lines = strings.Split(string(data), "\n")
+				}
+
+				startLine, endLine := directiveScope(pass.Fset, file, comment, lines)
+				directives = append(directives, &directive{
+					comment:   comment,
+					filename:  filename,
+					targets:   targets,
+					reason:    reason,
+					startLine: startLine,
+					endLine:   endLine,
+				})
+			}
+		}
+	}
+
+	return directives, nil
+}
+
+// parseDirective splits the directive into comma separated targets and the
+// reason, another comment after the directive is not a part of the reason.
+func parseDirective(text string) ([]string, string, bool) {
+	rest, ok := strings.CutPrefix(text, Directive)
+	if !ok || rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, "", false
+	}
+
+	rest, _, _ = strings.Cut(rest, "//")
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return nil, "", true
+	}
+
+	var targets []string
+	for _, target := range strings.Split(fields[0], ",") {
+		if target != "" {
+			targets = append(targets, target)
+		}
+	}
+
+	return targets, strings.Join(fields[1:], " "), true
+}
+
+// directiveScope returns the first and the last line covered by the
+// directive.
+func directiveScope(
+	fset *token.FileSet,
+	file *ast.File,
+	comment *ast.Comment,
+	lines []string,
+) (int, int) {
+
+	position := fset.PositionFor(comment.Pos(), false)
+	line := position.Line
+	if comment.Pos() < file.Package {
+		return 1, len(lines)
+	}
+
+	if strings.TrimSpace(lines[line-1][:position.Column-1]) != "" {
+		return line, line
+	}
+
+	for _, declaration := range file.Decls {
+		startLine := fset.PositionFor(declaration.Pos(), false).Line
+		doc := declarationDoc(declaration)
+		isDoc := doc != nil && doc.Pos() <= comment.Pos() && comment.End() <= doc.End()
+		if isDoc || line == startLine-1 {
+			return line, fset.PositionFor(declaration.End(), false).Line
+		}
+	}
+
+	return line + 1, line + 1
+}
+
+func declarationDoc(declaration ast.Decl) *ast.CommentGroup {
+	switch declaration := declaration.(type) {
+	case *ast.FuncDecl:
+		return declaration.Doc
+	case *ast.GenDecl:
+		return declaration.Doc
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package suppress
+
+import (
+	"fmt"
+	"go/ast"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jkuradobery/nbs-go-lint/line_breaks_analyzer"
+	"github.com/jkuradobery/nbs-go-lint/testcommon"
+)
+
+// newCallAnalyzer reports every function declaration and every call, so the
+// tests can suppress them by the analyzer name or by the category.
+func newCallAnalyzer() *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: "CallAnalyzer",
+		Doc:  "Reports functions and calls.",
+		Run: func(pass *analysis.Pass) (any, error) {
+			for _, file := range pass.Files {
+				ast.Inspect(file, func(node ast.Node) bool {
+					switch node := node.(type) {
+					case *ast.FuncDecl:
+						pass.Report(analysis.Diagnostic{
+							Pos:      node.Pos(),
+							Category: "functions",
+							Message:  fmt.Sprintf("function %s", node.Name.Name),
+						})
+					case *ast.CallExpr:
+						pass.Report(analysis.Diagnostic{
+							Pos:      node.Pos(),
+							Category: "calls",
+							Message:  "call",
+						})
+					}
+
+					return true
+				})
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+func TestWrap(t *testing.T) {
+	analyzer := newCallAnalyzer()
+	wrapped := Wrap(analyzer)
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		wrapped,
+		"suppressed/",
+	)
+
+	require.NotSame(t, analyzer, wrapped)
+	require.Nil(t, analyzer.ResultType)
+}
+
+func TestUnusedSuppressionAnalyzer(t *testing.T) {
+	analysistest.Run(
+		t,
+		testcommon.TestdataDir(t),
+		UnusedSuppressionAnalyzer(
+			[]string{"DisabledAnalyzer", "disabled"},
+			Wrap(newCallAnalyzer()),
+		),
+		"unused/",
+	)
+}
+
+func TestUnusedSuppressionAnalyzerExamples(t *testing.T) {
+	testcommon.RunExamples(
+		t,
+		testcommon.TestdataDir(t),
+		UnusedSuppressionAnalyzer(
+			nil,
+			Wrap(line_breaks_analyzer.LineBreakAfterRbracket()),
+		),
+		Rules(),
+	)
+}
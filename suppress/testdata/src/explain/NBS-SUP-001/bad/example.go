@@ -0,0 +1,16 @@
+package example
+
+import (
+	"fmt"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+func PrintPositive(values []int) {
+	for _, value := range values {
+		if value > 0 {
+			fmt.Println(value)
+		} //nbs:ignore line_breaks // want `Suppression requires analyzers or categories and a reason`
+		fmt.Println("checked")
+	}
+}
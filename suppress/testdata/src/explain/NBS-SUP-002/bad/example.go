@@ -0,0 +1,8 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Add(a int, b int) int {
+	//nbs:ignore line_breaks copied from the old parser // want "Unused suppression of line_breaks"
+	return a + b
+}
@@ -0,0 +1,7 @@
+package example
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Add(a int, b int) int {
+	return a + b
+}
@@ -0,0 +1,7 @@
+//nbs:ignore calls the calls of this file are checked elsewhere
+
+package suppressed
+
+func fileScope() { // want "function fileScope"
+	println()
+}
@@ -0,0 +1,32 @@
+package suppressed
+
+func lineScope() { // want "function lineScope"
+	println() //nbs:ignore calls the call is fine
+	println() // want "call"
+}
+
+func nextLineScope() { // want "function nextLineScope"
+	//nbs:ignore CallAnalyzer the next call is fine
+	println()
+	println() // want "call"
+}
+
+// declarationScope has a doc comment with a directive.
+//
+//nbs:ignore functions,calls the whole function is fine
+func declarationScope() {
+	println()
+}
+
+//nbs:ignore CallAnalyzer the whole function is fine
+func declarationScopeWithoutDoc() {
+	println()
+}
+
+func otherCategory() { // want "function otherCategory"
+	println() //nbs:ignore functions the directive does not cover calls // want "call"
+}
+
+func withoutReason() { // want "function withoutReason"
+	println() //nbs:ignore calls // want "call"
+}
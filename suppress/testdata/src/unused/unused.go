@@ -0,0 +1,17 @@
+package unused
+
+//nbs:ignore functions the function is fine
+func used() {
+	println() //nbs:ignore calls the call is fine
+}
+
+func unused() {
+	//nbs:ignore calls there is no call on the next line // want "Unused suppression of calls"
+
+	println() //nbs:ignore cals typo in the category // want "Unused suppression of cals"
+	println() //nbs:ignore calls,functions only calls are used // want "Unused suppression of functions"
+	println() //nbs:ignore calls // want `Suppression requires analyzers or categories and a reason`
+	println() //nbs:ignore // want `Suppression requires analyzers or categories and a reason`
+	println() //nbs:ignorecalls is not a directive
+	println() //nbs:ignore DisabledAnalyzer,disabled the analyzer is not run
+}
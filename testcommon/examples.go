@@ -0,0 +1,49 @@
+package testcommon
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jkuradobery/nbs-go-lint/rules"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+
+// RunExamples checks the examples of the rules shown by "nbs-go-lint explain":
+// the bad package has diagnostics of the rule marked with "// want" comments,
+// the good package has no diagnostics.
+func RunExamples(
+	t *testing.T,
+	testdata string,
+	analyzer *analysis.Analyzer,
+	rulesToCheck []rules.Rule,
+) {
+
+	t.Helper()
+	for _, rule := range rulesToCheck {
+		require.NotNil(t, rule.Examples, rule.Code)
+		for _, name := range []string{"bad", "good"} {
+			_, err := fs.Stat(rule.Examples, name)
+			require.NoError(t, err, rule.Code)
+		}
+
+		dir := "explain/" + rule.Code
+		results := analysistest.Run(t, testdata, analyzer, dir+"/bad", dir+"/good")
+		found := false
+		for _, result := range results {
+			for _, diagnostic := range result.Diagnostics {
+				position := result.Pass.Fset.Position(diagnostic.Pos)
+				require.Equal(t, "bad", filepath.Base(filepath.Dir(position.Filename)), rule.Code)
+				require.Equal(t, rules.URL(rule.Code), diagnostic.URL, rule.Code)
+				found = true
+			}
+		}
+
+		require.True(t, found, rule.Code)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+////////////////////////////////////////////////////////////////////////////////
+
 func TestdataDir(t *testing.T) string {
 	t.Helper()
 	_, testFilename, _, ok := runtime.Caller(1)
@@ -0,0 +1,13 @@
+package suppressions
+
+////////////////////////////////////////////////////////////////////////////////
+
+//nbs:ignore single_line the opt-in analyzer is not run by default
+func sum(a int, b int) int {
+	return a + b
+}
+
+//nbs:ignore separator the separator analyzer is run // want "Unused suppression of separator"
+func product(a int, b int) int {
+	return a * b
+}